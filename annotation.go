@@ -0,0 +1,92 @@
+package jsonschema
+
+// Annotation is the value of an annotation keyword
+// that applied to an instance location.
+type Annotation struct {
+	// Keyword that produced the annotation. ex: title
+	Keyword string
+
+	// absolute, dereferenced location of the schema
+	// containing the keyword.
+	SchemaURL string
+
+	// Value of the annotation.
+	Value any
+}
+
+type instanceAnnotation struct {
+	loc string // json-pointer of instance location
+	Annotation
+}
+
+// CollectAnnotations validates v and returns the annotations collected,
+// keyed by json-pointer of the instance location they apply to.
+//
+// Annotations are collected from keywords title, description, default,
+// readOnly, writeOnly, deprecated, examples, format and from vocabularies
+// using [ValidatorContext.Annotate].
+//
+// Annotations of subschemas that failed validation, such as unmatched
+// anyOf branches, are dropped. Annotations of subschemas reached through
+// $ref, $dynamicRef and $recursiveRef are included. If v is not valid,
+// the validation error is returned with no annotations.
+func (sch *Schema) CollectAnnotations(v any) (map[string][]Annotation, error) {
+	eval := &evaluation{collectAnnotations: true}
+	if err := sch.validate(v, nil, nil, nil, false, nil, eval); err != nil {
+		return nil, err
+	}
+	m := map[string][]Annotation{}
+	for _, a := range eval.annotations {
+		m[a.loc] = append(m[a.loc], a.Annotation)
+	}
+	return m, nil
+}
+
+func (e *evaluation) dropAnnotations(mark int) {
+	if len(e.annotations) > mark {
+		e.annotations = e.annotations[:mark]
+	}
+}
+
+func (vd *validator) annotate(keyword string, value any) {
+	if !vd.eval.collectAnnotations {
+		return
+	}
+	vd.eval.annotations = append(vd.eval.annotations, instanceAnnotation{
+		loc:        jsonPtr(vd.vloc),
+		Annotation: Annotation{keyword, vd.sch.Location, value},
+	})
+	if t := vd.eval.trace; t != nil {
+		node := t.top()
+		node.annotations = append(node.annotations, Annotation{keyword, vd.sch.Location, value})
+	}
+}
+
+// annotateSchema collects the annotations of vd.sch.
+func (vd *validator) annotateSchema() {
+	s := vd.sch
+	if s.Title != "" {
+		vd.annotate("title", s.Title)
+	}
+	if s.Description != "" {
+		vd.annotate("description", s.Description)
+	}
+	if s.Default != nil {
+		vd.annotate("default", *s.Default)
+	}
+	if s.ReadOnly {
+		vd.annotate("readOnly", true)
+	}
+	if s.WriteOnly {
+		vd.annotate("writeOnly", true)
+	}
+	if s.Deprecated {
+		vd.annotate("deprecated", true)
+	}
+	if len(s.Examples) > 0 {
+		vd.annotate("examples", s.Examples)
+	}
+	if s.formatName != "" {
+		vd.annotate("format", s.formatName)
+	}
+}
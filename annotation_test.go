@@ -0,0 +1,58 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollectAnnotations(t *testing.T) {
+	sch := compileString(t, `{
+		"$defs": {
+			"name": { "type": "string", "title": "Name", "description": "full name" }
+		},
+		"type": "object",
+		"title": "Person",
+		"properties": {
+			"name": { "$ref": "#/$defs/name" },
+			"nick": { "deprecated": true, "default": "" },
+			"age": {
+				"anyOf": [
+					{ "type": "string", "title": "age as string" },
+					{ "type": "integer", "title": "age as integer", "format": "int32" }
+				]
+			}
+		}
+	}`)
+	inst := unmarshalString(t, `{"name": "alice", "nick": "al", "age": 30}`)
+
+	m, err := sch.CollectAnnotations(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := func(loc string) map[string]any {
+		vals := map[string]any{}
+		for _, a := range m[loc] {
+			vals[a.Keyword] = a.Value
+		}
+		return vals
+	}
+	tests := []struct {
+		loc  string
+		want map[string]any
+	}{
+		{"", map[string]any{"title": "Person"}},
+		{"/name", map[string]any{"title": "Name", "description": "full name"}},
+		{"/nick", map[string]any{"deprecated": true, "default": ""}},
+		{"/age", map[string]any{"title": "age as integer", "format": "int32"}},
+	}
+	for _, test := range tests {
+		if got := values(test.loc); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %v, want %v", test.loc, got, test.want)
+		}
+	}
+
+	// invalid instance
+	if _, err := sch.CollectAnnotations(unmarshalString(t, `{"name": 1}`)); err == nil {
+		t.Error("validation must fail")
+	}
+}
@@ -0,0 +1,206 @@
+package jsonschema
+
+import (
+	"slices"
+)
+
+// Bundle compiles the schema at loc and returns a compound schema
+// document, which embeds all schema resources reachable from it,
+// so that it can be used without loading any other resources.
+//
+// Each document, other than the one with loc, is embedded in $defs
+// (definitions for draft < 2019) keyed by its $id. Its $id is set to
+// the absolute $id, which defaults to the url, and $schema is set if
+// its draft differs from that of loc. The document with loc gets
+// absolute $id as well. The references are left unchanged, which
+// resolve to the embedded resources by their $id. If a document
+// declares $id different from the url it is loaded from, an alias
+// schema with the url as $id, referring to the document, is added as
+// well. If loc has fragment, the bundle refers it using $ref.
+//
+// For draft < 2019, $id next to $ref is ignored. So document with
+// $ref at its root is wrapped in a schema with the $id, referring to
+// it from allOf.
+//
+// Metaschemas are not embedded, so custom metaschemas must be
+// made available to the consumers of the bundle.
+func (c *Compiler) Bundle(loc string) (any, error) {
+	sch, err := c.Compile(loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect documents reachable from sch
+	var urls []url
+	visited := map[*Schema]bool{}
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		if s == nil || visited[s] {
+			return
+		}
+		visited[s] = true
+		if !slices.Contains(urls, s.up.url) {
+			urls = append(urls, s.up.url)
+		}
+		for _, child := range s.linkedSchemas() {
+			visit(child)
+		}
+	}
+	visit(sch)
+
+	root := c.roots.roots[sch.up.url]
+	rootDraft := root.rootResource().dialect.draft
+	if sch.up.ptr != "" {
+		rootDraft = Draft2020
+	}
+	defs := map[string]any{}
+	for _, u := range urls {
+		if u == sch.up.url && sch.up.ptr == "" || isMeta(string(u)) {
+			continue
+		}
+		r := c.roots.roots[u]
+		obj, ok := copyJSON(r.doc).(map[string]any)
+		if !ok {
+			continue // boolean schema has no references to it
+		}
+		res := r.rootResource()
+		if _, ok := obj["$schema"]; !ok && res.dialect.draft != rootDraft {
+			obj["$schema"] = res.dialect.draft.url
+		}
+		defs[res.id.String()] = withID(res.dialect.draft, obj, res.id)
+		if res.id != u {
+			defs[u.String()] = aliasSchema(rootDraft, u, res.id)
+		}
+	}
+
+	var doc any
+	if sch.up.ptr == "" {
+		doc = copyJSON(root.doc)
+	} else {
+		up := urlPtr{root.rootResource().id, sch.up.ptr}
+		doc = map[string]any{
+			"$schema": rootDraft.url,
+			"$ref":    up.String(),
+		}
+	}
+	if id := root.rootResource().id; sch.up.ptr == "" && id != sch.up.url {
+		defs[sch.up.url.String()] = aliasSchema(rootDraft, sch.up.url, id)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok || len(defs) == 0 {
+		return doc, nil
+	}
+	if sch.up.ptr == "" {
+		obj = withID(rootDraft, obj, root.rootResource().id)
+		doc = obj
+	}
+	pname := "$defs"
+	if rootDraft.version < 2019 {
+		pname = "definitions"
+	}
+	container, ok := obj[pname].(map[string]any)
+	if !ok {
+		container = map[string]any{}
+		obj[pname] = container
+	}
+	for k, v := range defs {
+		container[k] = v
+	}
+	return doc, nil
+}
+
+// aliasSchema returns schema with id u, that refers to the
+// resource with given id. It is used in bundle for documents whose
+// $id differs from the url they are loaded from, so that references
+// using that url still resolve. allOf is used, because $ref ignores
+// sibling $id for draft < 2019.
+func aliasSchema(draft *Draft, u, id url) map[string]any {
+	return map[string]any{
+		draft.id: u.String(),
+		"allOf":  []any{map[string]any{"$ref": id.String()}},
+	}
+}
+
+// withID returns the document obj, with its $id set to id.
+// For draft < 2019, if obj has $ref, it returns a schema with id,
+// referring to obj from allOf. The definitions of obj are kept
+// in the returned schema, so that json-pointers into them resolve.
+func withID(draft *Draft, obj map[string]any, id url) map[string]any {
+	ref, ok := obj["$ref"]
+	if !ok || draft.version >= 2019 {
+		obj[draft.id] = id.String()
+		return obj
+	}
+	wrapper := map[string]any{
+		draft.id: id.String(),
+		"allOf":  []any{map[string]any{"$ref": ref}},
+	}
+	for _, k := range []string{"$schema", "definitions"} {
+		if v, ok := obj[k]; ok {
+			wrapper[k] = v
+		}
+	}
+	return wrapper
+}
+
+// linkedSchemas returns the subschemas of s, and the
+// schemas referred by s.
+func (s *Schema) linkedSchemas() []*Schema {
+	list := []*Schema{
+		s.Ref, s.RecursiveRef, s.Not, s.If, s.Then, s.Else,
+		s.PropertyNames, s.UnevaluatedProperties, s.Contains, s.Items2020,
+		s.UnevaluatedItems, s.ContentSchema,
+	}
+	if s.DynamicRef != nil {
+		list = append(list, s.DynamicRef.Ref)
+	}
+	for _, sch := range s.dynamicAnchors {
+		list = append(list, sch)
+	}
+	list = append(list, s.AllOf...)
+	list = append(list, s.AnyOf...)
+	list = append(list, s.OneOf...)
+	list = append(list, s.PrefixItems...)
+	for _, sch := range s.Properties {
+		list = append(list, sch)
+	}
+	for _, sch := range s.PatternProperties {
+		list = append(list, sch)
+	}
+	for _, sch := range s.DependentSchemas {
+		list = append(list, sch)
+	}
+	for _, dep := range s.Dependencies {
+		if sch, ok := dep.(*Schema); ok {
+			list = append(list, sch)
+		}
+	}
+	for _, v := range []any{s.AdditionalProperties, s.AdditionalItems, s.Items} {
+		switch v := v.(type) {
+		case *Schema:
+			list = append(list, v)
+		case []*Schema:
+			list = append(list, v...)
+		}
+	}
+	return slices.DeleteFunc(list, func(sch *Schema) bool { return sch == nil })
+}
+
+// copyJSON returns deep copy of json value v.
+func copyJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = copyJSON(item)
+		}
+		return m
+	case []any:
+		arr := make([]any, len(v))
+		for i, item := range v {
+			arr[i] = copyJSON(item)
+		}
+		return arr
+	}
+	return v
+}
@@ -0,0 +1,243 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestBundle(t *testing.T) {
+	docs := map[string]string{
+		"http://example.com/root.json": `{
+			"properties": {
+				"address": { "$ref": "address.json" },
+				"tags": { "$ref": "common.json#/$defs/tags" }
+			},
+			"required": ["address"]
+		}`,
+		"http://example.com/address.json": `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"properties": {
+				"city": { "type": "string" },
+				"zip": { "$ref": "http://example.com/common.json#/$defs/zip" }
+			},
+			"required": ["city"]
+		}`,
+		"http://example.com/common.json": `{
+			"$defs": {
+				"zip": { "type": "string", "pattern": "^[0-9]{5}$" },
+				"tags": { "type": "array", "items": { "$ref": "#/$defs/tag" } },
+				"tag": { "type": "string", "maxLength": 3 }
+			}
+		}`,
+		"http://example.com/unused.json": `{"type": "null"}`,
+	}
+	c := jsonschema.NewCompiler()
+	for u, doc := range docs {
+		if err := c.AddResource(u, unmarshalString(t, doc)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	instances := []struct {
+		data  string
+		valid bool
+	}{
+		{`{"address": {"city": "x", "zip": "12345"}, "tags": ["a"]}`, true},
+		{`{"address": {"city": "x", "zip": "1234"}}`, false},
+		{`{"address": {"zip": "12345"}}`, false},
+		{`{"address": {"city": "x"}, "tags": ["abcd"]}`, false},
+	}
+
+	bundle, err := c.Bundle("http://example.com/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := bundle.(map[string]any)
+	if obj["$id"] != "http://example.com/root.json" {
+		t.Errorf("$id: got %v", obj["$id"])
+	}
+	var keys []string
+	for k := range obj["$defs"].(map[string]any) {
+		keys = append(keys, k)
+	}
+	if len(keys) != 2 {
+		t.Errorf("$defs: got %v, want address.json and common.json", keys)
+	}
+
+	// compile bundle, without access to other documents
+	bc := jsonschema.NewCompiler()
+	if err := bc.AddResource("bundle.json", bundle); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bc.Compile("bundle.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, inst := range instances {
+		if got := sch.Validate(unmarshalString(t, inst.data)) == nil; got != inst.valid {
+			t.Errorf("%s: got %v, want %v", inst.data, got, inst.valid)
+		}
+	}
+
+	// original documents must not be modified
+	again, err := c.Bundle("http://example.com/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bundle, again) {
+		t.Error("bundle must be same on repeat")
+	}
+}
+
+func TestBundleFragment(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/defs.json", unmarshalString(t, `{
+		"$defs": {
+			"a": { "$ref": "#/$defs/b" },
+			"b": { "type": "integer" }
+		}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := c.Bundle("http://example.com/defs.json#/$defs/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := jsonschema.NewCompiler()
+	if err := bc.AddResource("bundle.json", bundle); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bc.Compile("bundle.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sch.Validate(1); err != nil {
+		t.Error(err)
+	}
+	if err := sch.Validate("1"); err == nil {
+		t.Error(`"1" must be invalid`)
+	}
+}
+
+func TestBundleDeclaredID(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	for u, doc := range map[string]string{
+		"http://example.com/root.json": `{
+			"$id": "http://example.com/v1/root.json",
+			"properties": { "other": { "$ref": "http://example.com/other.json" } }
+		}`,
+		"http://example.com/other.json": `{
+			"$id": "http://elsewhere.com/o.json",
+			"type": "object",
+			"properties": {
+				"name": { "type": "string" },
+				"root": { "$ref": "http://example.com/root.json" }
+			}
+		}`,
+	} {
+		if err := c.AddResource(u, unmarshalString(t, doc)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bundle, err := c.Bundle("http://example.com/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bc := jsonschema.NewCompiler()
+	if err := bc.AddResource("bundle.json", bundle); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bc.Compile("bundle.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sch.Validate(unmarshalString(t, `{"other": {"name": "x", "root": {"other": {}}}}`)); err != nil {
+		t.Error(err)
+	}
+	if err := sch.Validate(unmarshalString(t, `{"other": {"root": {"other": {"name": 1}}}}`)); err == nil {
+		t.Error("name must be string")
+	}
+}
+
+func TestBundleRelativeID(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	for u, doc := range map[string]string{
+		"http://a.com/x/root.json": `{
+			"properties": { "person": { "$ref": "../y/other.json" } }
+		}`,
+		"http://a.com/y/other.json": `{
+			"$id": "person.json",
+			"properties": { "name": { "type": "string" } }
+		}`,
+	} {
+		if err := c.AddResource(u, unmarshalString(t, doc)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bundle, err := c.Bundle("http://a.com/x/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs := bundle.(map[string]any)["$defs"].(map[string]any)
+	person, ok := defs["http://a.com/y/person.json"].(map[string]any)
+	if !ok || person["$id"] != "http://a.com/y/person.json" {
+		t.Fatalf("got %v, want person.json with absolute $id", defs)
+	}
+
+	bc := jsonschema.NewCompiler()
+	if err := bc.AddResource("http://a.com/x/root.json", bundle); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bc.Compile("http://a.com/x/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sch.Validate(unmarshalString(t, `{"person": {"name": 1}}`)); err == nil {
+		t.Error("name must be string")
+	}
+}
+
+func TestBundleDraft7RootRef(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	for u, doc := range map[string]string{
+		"http://example.com/root.json": `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"$ref": "other.json"
+		}`,
+		"http://example.com/other.json": `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"$ref": "#/definitions/name",
+			"definitions": {
+				"name": { "type": "string", "maxLength": 3 }
+			}
+		}`,
+	} {
+		if err := c.AddResource(u, unmarshalString(t, doc)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bundle, err := c.Bundle("http://example.com/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bundle.(map[string]any)["$ref"]; ok {
+		t.Errorf("$ref must be wrapped in allOf, because sibling $id is ignored")
+	}
+
+	bc := jsonschema.NewCompiler()
+	if err := bc.AddResource("bundle.json", bundle); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := bc.Compile("bundle.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sch.Validate("abc"); err != nil {
+		t.Error(err)
+	}
+	if err := sch.Validate("abcd"); err == nil {
+		t.Error(`"abcd" must be invalid`)
+	}
+}
@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"slices"
+	"sync"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Error messages are english printf formats, such as
+// "minLength: got %d, want %d", which double as keys in
+// message catalog. Translations for following languages
+// are registered in the catalog of this package, which is
+// used by printers created by [NewPrinter]:
+//
+//   - zh-Hans (also zh), zh-Hant, ja, de, fr
+//
+// Methods such as [ValidationError.LocalizedError] also accept
+// printers created by [message.NewPrinter] with the default
+// catalog; the catalog of this package is used for their language.
+// Printers with other catalogs are used as they are.
+
+var (
+	catalogMu   sync.Mutex
+	catalogTags = []language.Tag{language.English}
+
+	// messages is kept private, so that the translations of
+	// generic keys such as "at %s" do not leak into
+	// [message.DefaultCatalog] used by other packages.
+	messages = catalog.NewBuilder(catalog.Fallback(language.English))
+
+	printers sync.Map // map[language.Tag]*message.Printer, used by localize
+)
+
+// languageKey is the only key registered in [message.DefaultCatalog].
+// It translates to the language tag, so that the language of printers
+// created by [message.NewPrinter] can be found.
+const languageKey = "github.com/liuxd6825/jsonschema: language"
+
+func init() {
+	for _, c := range []struct {
+		tag      language.Tag
+		messages map[string]string
+	}{
+		{language.SimplifiedChinese, zhHansMessages},
+		{language.Chinese, zhHansMessages},
+		{language.TraditionalChinese, zhHantMessages},
+		{language.Japanese, jaMessages},
+		{language.German, deMessages},
+		{language.French, frMessages},
+	} {
+		if err := RegisterMessages(c.tag, c.messages); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterMessages registers translations of error messages for the
+// given language. translations maps english format, for example
+// "minLength: got %d, want %d", to its translation. Translations
+// can use explicit argument indexes such as %[2]d to reorder
+// arguments. Messages without translation are printed in english.
+//
+// Translations of messages that are already registered are
+// replaced.
+func RegisterMessages(tag language.Tag, translations map[string]string) error {
+	for key, msg := range translations {
+		if err := messages.SetString(tag, key, msg); err != nil {
+			return err
+		}
+	}
+	if err := message.SetString(tag, languageKey, tag.String()); err != nil {
+		return err
+	}
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if !slices.Contains(catalogTags, tag) {
+		catalogTags = append(catalogTags, tag)
+	}
+	return nil
+}
+
+// Languages returns the languages, which have messages registered.
+func Languages() []language.Tag {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	return slices.Clone(catalogTags)
+}
+
+// NewPrinter returns printer for the language that best matches
+// any of the given tags, among [Languages]. It falls back to english,
+// if none matches. Use it with methods such as
+// [ValidationError.LocalizedError].
+func NewPrinter(tags ...language.Tag) *message.Printer {
+	supported := Languages()
+	_, i, _ := language.NewMatcher(supported).Match(tags...)
+	return message.NewPrinter(supported[i], message.Catalog(messages))
+}
+
+// localize returns printer using the catalog of this package,
+// for the language of p. It returns p, if p is created by
+// [NewPrinter], or the language of p has no messages registered.
+func localize(p *message.Printer) *message.Printer {
+	s := p.Sprintf(languageKey)
+	if s == languageKey {
+		return p
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return p
+	}
+	if lp, ok := printers.Load(tag); ok {
+		return lp.(*message.Printer)
+	}
+	lp, _ := printers.LoadOrStore(tag, message.NewPrinter(tag, message.Catalog(messages)))
+	return lp.(*message.Printer)
+}
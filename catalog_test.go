@@ -0,0 +1,179 @@
+package jsonschema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// messageKeys returns the format strings passed to p.Sprintf
+// and LocalizableError in non-test sources of dirs.
+func messageKeys(t *testing.T, dirs ...string) []string {
+	t.Helper()
+	var keys []string
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pkg := range pkgs {
+			for _, f := range pkg.Files {
+				ast.Inspect(f, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok || len(call.Args) == 0 {
+						return true
+					}
+					switch fn := call.Fun.(type) {
+					case *ast.Ident:
+						if fn.Name != "LocalizableError" {
+							return true
+						}
+					case *ast.SelectorExpr:
+						if x, ok := fn.X.(*ast.Ident); !ok || x.Name != "p" || fn.Sel.Name != "Sprintf" {
+							return true
+						}
+					default:
+						return true
+					}
+					if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						key, err := strconv.Unquote(lit.Value)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if !slices.Contains(keys, key) {
+							keys = append(keys, key)
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+	return keys
+}
+
+var verbRegexp = regexp.MustCompile(`%(\[\d+\])?[a-zA-Z]`)
+
+// verbs returns the sorted verbs in format, ignoring explicit indexes.
+func verbs(format string) []string {
+	var list []string
+	for _, v := range verbRegexp.FindAllString(format, -1) {
+		list = append(list, v[len(v)-1:])
+	}
+	slices.Sort(list)
+	return list
+}
+
+func TestMessageCatalogs(t *testing.T) {
+	keys := messageKeys(t, ".", "kind")
+	if len(keys) < 100 {
+		t.Fatalf("found only %d message keys", len(keys))
+	}
+	catalogs := map[string]map[string]string{
+		"zh-Hans": zhHansMessages,
+		"zh-Hant": zhHantMessages,
+		"ja":      jaMessages,
+		"de":      deMessages,
+		"fr":      frMessages,
+	}
+	for lang, messages := range catalogs {
+		for _, key := range keys {
+			msg, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: missing translation for %q", lang, key)
+				continue
+			}
+			if !slices.Equal(verbs(key), verbs(msg)) {
+				t.Errorf("%s: verbs mismatch in %q: %q", lang, key, msg)
+			}
+		}
+		for key := range messages {
+			if !slices.Contains(keys, key) {
+				t.Errorf("%s: unused translation for %q", lang, key)
+			}
+		}
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	k := &kind.MinLength{Got: 2, Want: 3}
+	tests := []struct {
+		tag  language.Tag
+		want string
+	}{
+		{language.English, "minLength: got 2, want 3"},
+		{language.Chinese, "minLength：长度为 2，至少应为 3"},
+		{language.MustParse("zh-CN"), "minLength：长度为 2，至少应为 3"},
+		{language.MustParse("zh-TW"), "minLength：長度為 2，至少應為 3"},
+		{language.Japanese, "minLength: 長さは 2 です。3 以上である必要があります"},
+		{language.MustParse("de-AT"), "minLength: Länge 2, mindestens 3 erwartet"},
+		{language.French, "minLength : longueur 2, au moins 3 attendue"},
+		{language.Korean, "minLength: got 2, want 3"},
+	}
+	for _, test := range tests {
+		if got := k.LocalizedString(NewPrinter(test.tag)); got != test.want {
+			t.Errorf("%v: got %q, want %q", test.tag, got, test.want)
+		}
+	}
+
+	// format errors are localized with nested args
+	err := LocalizableError("invalid domain: %v", LocalizableError("consecutive dots"))
+	got := err.(*localizableError).LocalizedError(NewPrinter(language.German))
+	if want := "ungültige Domain: aufeinanderfolgende Punkte"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	tag := language.MustParse("es")
+	if err := RegisterMessages(tag, map[string]string{
+		"minLength: got %d, want %d": "minLength: longitud %d, se esperaba al menos %d",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(Languages(), tag) {
+		t.Fatalf("%v must be in Languages()", tag)
+	}
+	got := (&kind.MinLength{Got: 2, Want: 3}).LocalizedString(NewPrinter(language.MustParse("es-MX")))
+	if want := "minLength: longitud 2, se esperaba al menos 3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDefaultCatalogUntouched(t *testing.T) {
+	p := message.NewPrinter(language.German)
+	if got := p.Sprintf("at %s", "x"); got != "at x" {
+		t.Errorf("message.DefaultCatalog must not have translations: got %q", got)
+	}
+}
+
+func TestLocalizeMessagePrinter(t *testing.T) {
+	verr := &ValidationError{ErrorKind: &kind.MinLength{Got: 2, Want: 3}}
+	tests := []struct {
+		p    *message.Printer
+		want string
+	}{
+		{message.NewPrinter(language.Chinese), "minLength：长度为 2，至少应为 3"},
+		{message.NewPrinter(language.MustParse("de-AT")), "minLength: Länge 2, mindestens 3 erwartet"},
+		{message.NewPrinter(language.Korean), "minLength: got 2, want 3"},
+		{NewPrinter(language.French), "minLength : longueur 2, au moins 3 attendue"},
+	}
+	for _, test := range tests {
+		if got := verr.LocalizedError(test.p); !strings.HasSuffix(got, test.want) {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
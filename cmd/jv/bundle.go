@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	flag "github.com/spf13/pflag"
+)
+
+// bundleMain implements the bundle subcommand, which emits
+// schema along with all resources it refers, or with all
+// references inlined.
+func bundleMain(args []string) {
+	fs := flag.NewFlagSet("bundle", flag.ExitOnError)
+	fs.Usage = func() {
+		eprintln("Usage: jv bundle [OPTIONS] SCHEMA")
+		eprintln("")
+		eprintln("Bundles SCHEMA and the resources it refers into single schema document.")
+		eprintln("With --deref, the references are inlined instead.")
+		eprintln("")
+		eprintln("With --max-depth N, a recursive schema is inlined N times within itself.")
+		eprintln("Beyond that, the subschema holding the recursive reference is replaced")
+		eprintln("with true, dropping its other keywords such as type and properties, so")
+		eprintln("the inlined schema accepts more than the original at that depth.")
+		eprintln("")
+		eprintln("Options:")
+		fs.PrintDefaults()
+	}
+	help := fs.BoolP("help", "h", false, "Print help information")
+	deref := fs.Bool("deref", false, "Inline references, so that the bundled schema has no $ref")
+	maxDepth := fs.Int("max-depth", 0, "Number of times a recursive schema is nested within itself with --deref. Zero means recursion is an error")
+	output := fs.StringP("output", "o", "", "Write bundled schema to `file` instead of standard output")
+	insecure := fs.BoolP("insecure", "k", false, "Use insecure TLS connection")
+	cacert := fs.String("cacert", "", "Use the specified `pem-file` to verify the peer. The file may contain multiple CA certificates")
+	maps := fs.StringArrayP("map", "m", nil, "load url with prefix from given directory. Syntax `url_prefix=/path/to/dir`")
+	fs.SortFlags = false
+	fs.Parse(args)
+
+	if *help {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if fs.NArg() != 1 {
+		eprintln("want SCHEMA")
+		eprintln("")
+		fs.Usage()
+		os.Exit(2)
+	}
+	mappings, err := parseMappings(*maps)
+	if err != nil {
+		eprintln("%v", err)
+		eprintln("")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c := jsonschema.NewCompiler()
+	loader, err := newLoader(mappings, *insecure, *cacert)
+	if err != nil {
+		eprintln("%v", err)
+		os.Exit(2)
+	}
+	c.UseLoader(loader)
+	schema := fs.Arg(0)
+	var doc any
+	if *deref {
+		var sch *jsonschema.Schema
+		if sch, err = c.Compile(schema); err == nil {
+			doc, err = sch.Dereference(jsonschema.DereferenceOptions{MaxDepth: *maxDepth})
+		}
+	} else {
+		doc, err = c.Bundle(schema)
+	}
+	if err != nil {
+		eprintln("schema %s: failed", schema)
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+
+	b, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+	b = append(b, '\n')
+	if *output == "" {
+		fmt.Print(string(b))
+		return
+	}
+	if err := os.WriteFile(*output, b, 0o644); err != nil {
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	flag "github.com/spf13/pflag"
+)
+
+// genMain implements the gen subcommand, which generates
+// source code from schema.
+func genMain(args []string) {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	fs.Usage = func() {
+		eprintln("Usage: jv gen go [OPTIONS] SCHEMA")
+		eprintln("")
+		eprintln("Generates go types for SCHEMA.")
+		eprintln("")
+		eprintln("Options:")
+		fs.PrintDefaults()
+	}
+	help := fs.BoolP("help", "h", false, "Print help information")
+	pkg := fs.StringP("package", "p", "main", "Package `name` of generated source")
+	typeName := fs.StringP("type", "t", "", "Type `name` for SCHEMA. Defaults to title of SCHEMA or else its file name")
+	output := fs.StringP("output", "o", "", "Write generated source to `file` instead of standard output")
+	insecure := fs.BoolP("insecure", "k", false, "Use insecure TLS connection")
+	cacert := fs.String("cacert", "", "Use the specified `pem-file` to verify the peer. The file may contain multiple CA certificates")
+	maps := fs.StringArrayP("map", "m", nil, "load url with prefix from given directory. Syntax `url_prefix=/path/to/dir`")
+	fs.SortFlags = false
+	fs.Parse(args)
+
+	if *help {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if fs.NArg() != 2 {
+		eprintln("want language and SCHEMA")
+		eprintln("")
+		fs.Usage()
+		os.Exit(2)
+	}
+	if lang := fs.Arg(0); lang != "go" {
+		eprintln("unsupported language: %v", lang)
+		eprintln("")
+		fs.Usage()
+		os.Exit(2)
+	}
+	mappings, err := parseMappings(*maps)
+	if err != nil {
+		eprintln("%v", err)
+		eprintln("")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c := jsonschema.NewCompiler()
+	loader, err := newLoader(mappings, *insecure, *cacert)
+	if err != nil {
+		eprintln("%v", err)
+		os.Exit(2)
+	}
+	c.UseLoader(loader)
+	schema := fs.Arg(1)
+	sch, err := c.Compile(schema)
+	if err != nil {
+		eprintln("schema %s: failed", schema)
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+
+	src, err := jsonschema.GenerateGo(sch, *pkg, *typeName)
+	if err != nil {
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		fmt.Print(string(src))
+		return
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		eprintln("%v", err)
+		os.Exit(1)
+	}
+}
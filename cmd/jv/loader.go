@@ -14,7 +14,7 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-func newLoader(mappings map[string]string, insecure bool, cacert string) (jsonschema.URLLoader, error) {
+func newLoader(mappings map[string]string, insecure bool, cacert string) (*JVLoader, error) {
 	httpLoader := HTTPLoader(http.Client{
 		Timeout: 15 * time.Second,
 	})
@@ -34,7 +34,8 @@ func newLoader(mappings map[string]string, insecure bool, cacert string) (jsonsc
 		}
 	}
 	return &JVLoader{
-		mappings: mappings,
+		mappings:  mappings,
+		positions: map[string]*jsonschema.SourcePositions{},
 		fallback: jsonschema.SchemeURLLoader{
 			"file":  FileLoader{},
 			"http":  &httpLoader,
@@ -45,31 +46,55 @@ func newLoader(mappings map[string]string, insecure bool, cacert string) (jsonsc
 // --
 
 type JVLoader struct {
-	mappings map[string]string
-	fallback jsonschema.URLLoader
+	mappings  map[string]string
+	fallback  jsonschema.URLLoader
+	positions map[string]*jsonschema.SourcePositions // of json files loaded, keyed by url
 }
 
 func (l *JVLoader) Load(url string) (any, error) {
+	path := ""
 	for prefix, dir := range l.mappings {
 		if suffix, ok := strings.CutPrefix(url, prefix); ok {
-			return loadFile(filepath.Join(dir, suffix))
+			path = filepath.Join(dir, suffix)
+			break
 		}
 	}
-	return l.fallback.Load(url)
+	if path == "" && strings.HasPrefix(url, "file:") {
+		p, err := jsonschema.FileLoader{}.ToFile(url)
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	if path == "" {
+		return l.fallback.Load(url)
+	}
+	v, pos, err := loadFile(path)
+	if pos != nil {
+		l.positions[url] = pos
+	}
+	return v, err
+}
+
+// positionsOf returns positions of json file loaded from url.
+func (l *JVLoader) positionsOf(url string) *jsonschema.SourcePositions {
+	return l.positions[url]
 }
 
-func loadFile(path string) (any, error) {
+// loadFile loads json or yaml file. positions are
+// returned only for json files.
+func loadFile(path string) (any, *jsonschema.SourcePositions, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer f.Close()
 	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
 		var v any
 		err := yaml.NewDecoder(f).Decode(&v)
-		return v, err
+		return v, nil, err
 	}
-	return jsonschema.UnmarshalJSON(f)
+	return jsonschema.UnmarshalJSONWithPositions(f, path)
 }
 
 // --
@@ -81,7 +106,8 @@ func (l FileLoader) Load(url string) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	return loadFile(path)
+	v, _, err := loadFile(path)
+	return v, err
 }
 
 // --
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"runtime/debug"
@@ -11,11 +12,24 @@ import (
 
 	"github.com/liuxd6825/jsonschema/v6"
 	flag "github.com/spf13/pflag"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "gen" {
+		genMain(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "bundle" {
+		bundleMain(os.Args[2:])
+		return
+	}
+
 	flag.Usage = func() {
 		eprintln("Usage: jv [OPTIONS] SCHEMA [INSTANCE...]")
+		eprintln("       jv gen go [OPTIONS] SCHEMA")
+		eprintln("       jv bundle [OPTIONS] SCHEMA")
 		eprintln("")
 		eprintln("Options:")
 		flag.PrintDefaults()
@@ -24,12 +38,16 @@ func main() {
 	version := flag.BoolP("version", "v", false, "Print build information")
 	quiet := flag.BoolP("quiet", "q", false, "Do not print errors")
 	draftVersion := flag.IntP("draft", "d", 2020, "Draft `version` used when '$schema' is missing. Valid values 4, 6, 7, 2019, 2020")
-	output := flag.StringP("output", "o", "simple", "Output `format`. Valid values simple, alt, flag, basic, detailed")
+	output := flag.StringP("output", "o", "simple", "Output `format`. Valid values simple, alt, flag, basic, detailed, verbose, list, hierarchical, best")
 	assertFormat := flag.BoolP("assert-format", "f", false, "Enable format assertions with draft >= 2019")
 	assertContent := flag.BoolP("assert-content", "c", false, "Enable content assertions with draft >= 7")
 	insecure := flag.BoolP("insecure", "k", false, "Use insecure TLS connection")
 	cacert := flag.String("cacert", "", "Use the specified `pem-file` to verify the peer. The file may contain multiple CA certificates")
 	maps := flag.StringArrayP("map", "m", nil, "load url with prefix from given directory. Syntax `url_prefix=/path/to/dir`")
+	ndjson := flag.Bool("ndjson", false, "Validate each line of INSTANCE as separate json document. Output formats verbose, list and hierarchical are not supported")
+	jobs := flag.IntP("jobs", "j", 0, "Number of lines validated concurrently with --ndjson. Defaults to number of CPUs")
+	suggestFix := flag.Bool("suggest-fix", false, "Print JSON Patch that fixes common errors in invalid INSTANCE. Not supported with --ndjson")
+	lang := flag.String("lang", "en", "Language `tag` of error messages. ex: zh-Hans, zh-Hant, ja, de, fr")
 	flag.CommandLine.SortFlags = false
 	flag.Parse()
 
@@ -73,36 +91,41 @@ func main() {
 	}
 
 	// output --
-	if !slices.Contains([]string{"simple", "alt", "flag", "basic", "detailed"}, *output) {
+	if !slices.Contains([]string{"simple", "alt", "flag", "basic", "detailed", "verbose", "list", "hierarchical", "best"}, *output) {
 		eprintln("invalid output: %v", *output)
 		eprintln("")
 		flag.Usage()
 		os.Exit(2)
 	}
 
+	// suggest-fix --
+	if *suggestFix && *ndjson {
+		eprintln("--suggest-fix is not supported with --ndjson")
+		eprintln("")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// ndjson --
+	if *ndjson && slices.Contains([]string{"verbose", "list", "hierarchical"}, *output) {
+		eprintln("output %v is not supported with --ndjson", *output)
+		eprintln("")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// lang --
+	tag, err := language.Parse(*lang)
+	if err != nil {
+		eprintln("invalid lang %v: %v", *lang, err)
+		eprintln("")
+		flag.Usage()
+		os.Exit(2)
+	}
+	p := jsonschema.NewPrinter(tag)
+
 	// maps --
-	mappings, err := func() (map[string]string, error) {
-		mappings := map[string]string{}
-		for _, m := range *maps {
-			equal := strings.IndexByte(m, '=')
-			if equal == -1 {
-				return nil, fmt.Errorf("invalid map: %v", m)
-			}
-			u, dir := m[:equal], m[equal+1:]
-			if dir == "" {
-				return nil, fmt.Errorf("invalid map: %v", m)
-			}
-			_, err := url.Parse(u)
-			if err != nil {
-				return nil, fmt.Errorf("invalid map %v: %v", m, err)
-			}
-			if !strings.HasSuffix(u, "/") {
-				u += "/"
-			}
-			mappings[u] = dir
-		}
-		return mappings, nil
-	}()
+	mappings, err := parseMappings(*maps)
 	if err != nil {
 		eprintln("%v", err)
 		eprintln("")
@@ -157,6 +180,15 @@ func main() {
 	if err != nil {
 		fmt.Printf("schema %s: failed\n", schema)
 		if !*quiet {
+			if serr, ok := err.(*jsonschema.SchemaValidationError); ok {
+				if verr, ok := serr.Err.(*jsonschema.ValidationError); ok {
+					u, _, _ := strings.Cut(serr.URL, "#")
+					verr.ResolvePositions(loader.positionsOf(u), nil)
+				}
+			} else if pos, ok := jsonschema.SchemaErrorPosition(err, loader.positionsOf); ok {
+				fmt.Printf("%v (%s)\n", err, pos)
+				os.Exit(1)
+			}
 			fmt.Println(err)
 		}
 		os.Exit(1)
@@ -169,11 +201,17 @@ func main() {
 		if !*quiet {
 			fmt.Println()
 		}
-		inst, err := func() (any, error) {
+		if *ndjson {
+			if !validateNDJSON(sch, loader, instance, stdinDecoder, *jobs, *quiet, *output, p) {
+				allValid = false
+			}
+			continue
+		}
+		inst, instPos, err := func() (any, *jsonschema.SourcePositions, error) {
 			if instance == "-" {
 				var inst any
 				err := stdinDecoder.Decode(&inst)
-				return inst, err
+				return inst, nil, err
 			}
 			return loadFile(instance)
 		}()
@@ -186,25 +224,40 @@ func main() {
 			continue
 		}
 
+		if slices.Contains([]string{"verbose", "list", "hierarchical"}, *output) {
+			res := sch.Evaluate(inst)
+			if res.Valid() {
+				fmt.Printf("instance %s: ok\n", instance)
+			} else {
+				fmt.Printf("instance %s: failed\n", instance)
+				allValid = false
+			}
+			if !*quiet {
+				switch *output {
+				case "verbose":
+					printJSON(res.LocalizedVerboseOutput(p))
+				case "list":
+					printJSON(res.LocalizedListOutput(p))
+				case "hierarchical":
+					printJSON(res.LocalizedHierarchicalOutput(p))
+				}
+				if *suggestFix && !res.Valid() {
+					printFix(sch.Validate(inst), inst)
+				}
+			}
+			continue
+		}
+
 		err = sch.Validate(inst)
 		if err != nil {
+			if verr, ok := err.(*jsonschema.ValidationError); ok {
+				verr.ResolvePositions(instPos, loader.positionsOf)
+			}
 			fmt.Printf("instance %s: failed\n", instance)
 			if !*quiet {
-				if verr, ok := err.(*jsonschema.ValidationError); ok {
-					switch *output {
-					case "simple":
-						fmt.Printf("%v\n", verr)
-					case "alt":
-						fmt.Printf("%#v\n", verr)
-					case "flag":
-						printJSON(verr.FlagOutput())
-					case "basic":
-						printJSON(verr.BasicOutput())
-					case "detailed":
-						printJSON(verr.DetailedOutput())
-					}
-				} else {
-					fmt.Println(err)
+				printError(err, *output, p)
+				if *suggestFix {
+					printFix(err, inst)
 				}
 			}
 			allValid = false
@@ -217,6 +270,109 @@ func main() {
 	}
 }
 
+// validateNDJSON validates each line of instance and
+// reports whether all lines are valid.
+func validateNDJSON(sch *jsonschema.Schema, loader *JVLoader, instance string, stdinDecoder *json.Decoder, jobs int, quiet bool, output string, p *message.Printer) bool {
+	var r io.Reader
+	if instance == "-" {
+		r = io.MultiReader(stdinDecoder.Buffered(), os.Stdin)
+	} else {
+		f, err := os.Open(instance)
+		if err != nil {
+			fmt.Printf("instance %s: failed\n", instance)
+			if !quiet {
+				fmt.Println(err)
+			}
+			return false
+		}
+		defer f.Close()
+		r = f
+	}
+
+	allValid := true
+	err := sch.ValidateNDJSON(r, jobs, func(res jsonschema.LineResult) bool {
+		if res.Err == nil {
+			fmt.Printf("instance %s:%d: ok\n", instance, res.Line)
+			return true
+		}
+		allValid = false
+		if verr, ok := res.Err.(*jsonschema.ValidationError); ok {
+			verr.ResolvePositions(nil, loader.positionsOf)
+		}
+		fmt.Printf("instance %s:%d: failed\n", instance, res.Line)
+		if !quiet {
+			printError(res.Err, output, p)
+		}
+		return true
+	})
+	if err != nil {
+		fmt.Printf("instance %s: failed\n", instance)
+		if !quiet {
+			fmt.Println(err)
+		}
+		return false
+	}
+	return allValid
+}
+
+func printError(err error, output string, p *message.Printer) {
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		fmt.Println(err)
+		return
+	}
+	switch output {
+	case "simple":
+		fmt.Println(verr.LocalizedError(p))
+	case "alt":
+		fmt.Println(verr.LocalizedGoString(p))
+	case "flag":
+		printJSON(verr.FlagOutput())
+	case "basic":
+		printJSON(verr.LocalizedBasicOutput(p))
+	case "detailed":
+		printJSON(verr.LocalizedDetailedOutput(p))
+	case "best":
+		fmt.Println(verr.BestMatch().LocalizedError(p))
+	}
+}
+
+// printFix prints the patch suggested for inst, if any.
+func printFix(err error, inst any) {
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return
+	}
+	if patch := verr.SuggestPatch(inst); len(patch) > 0 {
+		fmt.Println("suggested fix:")
+		printJSON(patch)
+	}
+}
+
+// parseMappings parses values of --map flag.
+func parseMappings(maps []string) (map[string]string, error) {
+	mappings := map[string]string{}
+	for _, m := range maps {
+		equal := strings.IndexByte(m, '=')
+		if equal == -1 {
+			return nil, fmt.Errorf("invalid map: %v", m)
+		}
+		u, dir := m[:equal], m[equal+1:]
+		if dir == "" {
+			return nil, fmt.Errorf("invalid map: %v", m)
+		}
+		_, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid map %v: %v", m, err)
+		}
+		if !strings.HasSuffix(u, "/") {
+			u += "/"
+		}
+		mappings[u] = dir
+	}
+	return mappings, nil
+}
+
 func eprintln(format string, args ...any) {
 	fmt.Fprintf(os.Stderr, format, args...)
 	fmt.Fprintln(os.Stderr)
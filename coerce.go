@@ -0,0 +1,71 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// CoerceTypes validates v, converting values whose type does not match
+// the type keyword of schema, and returns v with coercions applied. This
+// is useful to validate values coming from query parameters, form data
+// or environment variables, which are always strings.
+//
+// Following conversions are attempted, in that order:
+//   - string to number/integer, if it is a valid json number.
+//     boolean to number/integer as 1 or 0
+//   - string "true" or "false" to boolean
+//   - string "" or "null" to null
+//   - non-array value to single element array
+//
+// Values which cannot be coerced are reported with error kind
+// [kind.Coercion].
+//
+// NOTE: objects and arrays in v are modified in place.
+func (sch *Schema) CoerceTypes(v any) (any, error) {
+	eval := &evaluation{coerceTypes: true}
+	err := sch.validate(v, nil, nil, nil, false, nil, eval)
+	return eval.instance, err
+}
+
+// coerce converts v to one of the types.
+func coerce(v any, types Types) (any, bool) {
+	if types.contains(numberType) || types.contains(integerType) {
+		var num json.Number
+		switch v := v.(type) {
+		case string:
+			if _, ok := new(big.Rat).SetString(v); ok && isJSONNumber(v) {
+				num = json.Number(v)
+			}
+		case bool:
+			num = "0"
+			if v {
+				num = "1"
+			}
+		}
+		if num != "" && (types.contains(numberType) || isInteger(num)) {
+			return num, true
+		}
+	}
+	if s, ok := v.(string); ok {
+		if types.contains(booleanType) && (s == "true" || s == "false") {
+			return s == "true", true
+		}
+		if types.contains(nullType) && (s == "" || s == "null") {
+			return nil, true
+		}
+	}
+	if types.contains(arrayType) {
+		if _, ok := v.([]any); !ok {
+			if _, ok := v.(map[string]any); !ok {
+				return []any{v}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// isJSONNumber tells whether s is valid json number literal.
+func isJSONNumber(s string) bool {
+	var num json.Number
+	return json.Unmarshal([]byte(s), &num) == nil
+}
@@ -0,0 +1,73 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+func TestCoerceTypes(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "object",
+		"properties": {
+			"page": { "type": "integer", "minimum": 1 },
+			"ratio": { "type": "number" },
+			"debug": { "type": "boolean" },
+			"filter": { "type": ["null", "string"] },
+			"tags": { "type": "array", "items": { "type": "string" } },
+			"ids": { "type": "array", "items": { "type": "integer" } },
+			"cursor": { "type": "null" }
+		}
+	}`)
+	inst := map[string]any{
+		"page":   "2",
+		"ratio":  "0.5",
+		"debug":  "true",
+		"filter": "",
+		"tags":   "go",
+		"ids":    []any{"1", "2"},
+		"cursor": "",
+	}
+	got, err := sch.CoerceTypes(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"cursor":null,"debug":true,"filter":"","ids":[1,2],"page":2,"ratio":0.5,"tags":["go"]}`
+	if b, _ := json.Marshal(got); string(b) != want {
+		t.Errorf("got %s\nwant %s", b, want)
+	}
+
+	tests := []struct {
+		inst map[string]any
+		msg  string
+	}{
+		{map[string]any{"page": "abc"}, "cannot coerce 'abc' to integer"},
+		{map[string]any{"page": "1.5"}, "cannot coerce '1.5' to integer"},
+		{map[string]any{"page": "0"}, "minimum: got 0, want 1"},
+		{map[string]any{"debug": "yes"}, "cannot coerce 'yes' to boolean"},
+	}
+	for _, test := range tests {
+		_, err := sch.CoerceTypes(test.inst)
+		if err == nil {
+			t.Errorf("%v: validation must fail", test.inst)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.msg) {
+			t.Errorf("%v: got %q, want %q", test.inst, err, test.msg)
+		}
+	}
+
+	// coercion errors have their own kind
+	sch = compileString(t, `{"type": "integer"}`)
+	_, err = sch.CoerceTypes("x")
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok || len(verr.Causes) != 1 {
+		t.Fatalf("got %#v", err)
+	}
+	if _, ok := verr.Causes[0].ErrorKind.(*kind.Coercion); !ok {
+		t.Fatalf("got %T, want *kind.Coercion", verr.Causes[0].ErrorKind)
+	}
+}
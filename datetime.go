@@ -0,0 +1,148 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// Values of types date and datetime are either [time.Time] or
+// strings in RFC 3339 format. time.Time conforms to both date and
+// datetime. A string conforms to date if it is RFC 3339 full-date,
+// ex: "2024-02-29", and to datetime if it is RFC 3339 date-time,
+// ex: "2024-02-29T10:30:00Z".
+
+// temporalTypes returns the types among date and datetime,
+// that v conforms to.
+func temporalTypes(v any) Types {
+	var types Types
+	switch v := v.(type) {
+	case time.Time:
+		types.add(dateType)
+		types.add(dateTimeType)
+	case *time.Time:
+		if v != nil {
+			types.add(dateType)
+			types.add(dateTimeType)
+		}
+	case string:
+		if len(v) == len("2006-01-02") {
+			if validateDate(v) == nil {
+				types.add(dateType)
+			}
+		} else if validateDateTime(v) == nil {
+			types.add(dateTimeType)
+		}
+	}
+	return types
+}
+
+// containsTemporal tells whether v conforms to date or datetime
+// type in tt.
+func (tt Types) containsTemporal(v any) bool {
+	if !tt.contains(dateType) && !tt.contains(dateTimeType) {
+		return false
+	}
+	return int(tt)&int(temporalTypes(v)) != 0
+}
+
+// parseTemporal returns v as time.Time, if v is of type date or datetime.
+// date is returned as midnight UTC.
+func parseTemporal(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		types := temporalTypes(v)
+		if types.contains(dateType) {
+			t, err := time.Parse(time.DateOnly, v)
+			return t, err == nil
+		}
+		if types.contains(dateTimeType) {
+			// note: leap seconds are not supported by time package
+			t, err := time.Parse(time.RFC3339Nano, strings.ToUpper(v))
+			return t, err == nil
+		}
+	}
+	return time.Time{}, false
+}
+
+// dateValidate validates the bounds of date and datetime values.
+func (vd *validator) dateValidate(v any) {
+	s := vd.sch
+	if s.DateMinimum == nil && s.DateMaximum == nil && s.DateExclusiveMinimum == nil && s.DateExclusiveMaximum == nil {
+		return
+	}
+	t, ok := parseTemporal(v)
+	if !ok {
+		return
+	}
+	got := func() string {
+		if str, ok := v.(string); ok {
+			return str
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+
+	// dateMinimum --
+	if s.DateMinimum != nil && t.Before(s.DateMinimum.Time) {
+		vd.addError(&kind.DateMinimum{Got: got(), Want: s.DateMinimum.Value})
+	}
+
+	// dateMaximum --
+	if s.DateMaximum != nil && t.After(s.DateMaximum.Time) {
+		vd.addError(&kind.DateMaximum{Got: got(), Want: s.DateMaximum.Value})
+	}
+
+	// dateExclusiveMinimum --
+	if s.DateExclusiveMinimum != nil && !t.After(s.DateExclusiveMinimum.Time) {
+		vd.addError(&kind.DateExclusiveMinimum{Got: got(), Want: s.DateExclusiveMinimum.Value})
+	}
+
+	// dateExclusiveMaximum --
+	if s.DateExclusiveMaximum != nil && !t.Before(s.DateExclusiveMaximum.Time) {
+		vd.addError(&kind.DateExclusiveMaximum{Got: got(), Want: s.DateExclusiveMaximum.Value})
+	}
+}
+
+// DateBound is the value of keywords dateMinimum, dateMaximum,
+// dateExclusiveMinimum and dateExclusiveMaximum.
+type DateBound struct {
+	// Value as specified in schema.
+	Value string
+
+	// Time is parsed Value. date is parsed as midnight UTC.
+	Time time.Time
+}
+
+// dateBound returns the value of date bound keyword pname,
+// which must be RFC 3339 date or date-time string.
+func (c *objCompiler) dateBound(pname string) (*DateBound, error) {
+	v, ok := c.obj[pname]
+	if !ok {
+		return nil, nil
+	}
+	if s, ok := v.(string); ok {
+		if t, ok := parseTemporal(s); ok {
+			return &DateBound{s, t}, nil
+		}
+	}
+	return nil, &InvalidDateBoundError{c.up.format(pname), v}
+}
+
+// InvalidDateBoundError is returned by compiler, if value of date
+// bound keyword is not RFC 3339 date or date-time string.
+type InvalidDateBoundError struct {
+	URL   string
+	Value any
+}
+
+func (e *InvalidDateBoundError) Error() string {
+	return fmt.Sprintf("invalid date bound %v at %q", e.Value, e.URL)
+}
@@ -0,0 +1,96 @@
+package jsonschema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+func TestDateTypes(t *testing.T) {
+	date := compileString(t, `{"type": "date"}`)
+	datetime := compileString(t, `{"type": "datetime"}`)
+	tests := []struct {
+		v        any
+		date     bool
+		datetime bool
+	}{
+		{time.Now(), true, true},
+		{func() *time.Time { t := time.Now(); return &t }(), true, true},
+		{"2024-02-29", true, false},
+		{"2023-02-29", false, false},
+		{"2024-02-29T10:30:00Z", false, true},
+		{"2024-02-29t10:30:00.5+05:30", false, true},
+		{"2024-02-29 10:30:00", false, false},
+		{"now", false, false},
+		{nil, false, false},
+		{1, false, false},
+	}
+	for _, test := range tests {
+		if got := date.Validate(test.v) == nil; got != test.date {
+			t.Errorf("date %v: got %v, want %v", test.v, got, test.date)
+		}
+		if got := datetime.Validate(test.v) == nil; got != test.datetime {
+			t.Errorf("datetime %v: got %v, want %v", test.v, got, test.datetime)
+		}
+	}
+
+	// type error message
+	err := date.Validate("2024-02-29T10:30:00Z")
+	if k, ok := err.(*jsonschema.ValidationError).Causes[0].ErrorKind.(*kind.Type); !ok || k.Got != "string" || k.Want[0] != "date" {
+		t.Errorf("got %v", err)
+	}
+
+	// string type is not affected
+	str := compileString(t, `{"type": "string"}`)
+	if err := str.Validate("2024-02-29"); err != nil {
+		t.Error(err)
+	}
+	if err := str.Validate(time.Now()); err == nil {
+		t.Error("time.Time must not be valid string")
+	}
+}
+
+func TestDateBounds(t *testing.T) {
+	sch := compileString(t, `{
+		"type": ["date", "datetime"],
+		"dateMinimum": "2024-01-01",
+		"dateExclusiveMaximum": "2024-12-31T00:00:00Z"
+	}`)
+	tests := []struct {
+		v     any
+		valid bool
+	}{
+		{"2024-01-01", true},
+		{"2023-12-31", false},
+		{"2023-12-31T23:59:59Z", false},
+		{"2024-01-01T05:00:00+01:00", true},
+		{"2024-01-01T05:00:00+05:30", false},
+		{"2024-12-30", true},
+		{"2024-12-31", false},
+		{time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		if got := sch.Validate(test.v) == nil; got != test.valid {
+			t.Errorf("%v: got %v, want %v", test.v, got, test.valid)
+		}
+	}
+
+	// bounds must be strings
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", map[string]any{"dateMinimum": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Compile("schema.json"); err == nil {
+		t.Error("non-string dateMinimum must fail compilation")
+	}
+}
+
+func TestEnumWithoutDateTypes(t *testing.T) {
+	sch := compileString(t, `{"enum": ["a", "b"]}`)
+	if err := sch.Validate("date"); err == nil {
+		t.Error(`"date" must not be valid against enum ["a", "b"]`)
+	}
+}
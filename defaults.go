@@ -0,0 +1,142 @@
+package jsonschema
+
+// ApplyDefaults validates v and fills in the defaults specified
+// in schema while validating. It returns v with defaults applied.
+//
+// Missing properties are set to the default of their schema in
+// properties. Missing trailing items of an array are set to the
+// default of their schema in prefixItems (items for draft < 2020).
+//
+// Defaults are taken from schemas that apply unconditionally, including
+// those reached via $ref and allOf, and from the then/else branch that
+// is actually taken. Subschemas whose result is not known beforehand,
+// such as anyOf, oneOf and if, do not apply their defaults. The branch
+// is chosen by evaluating if before its defaults are filled in.
+//
+// Defaults of properties, including those from allOf subschemas and
+// the branch taken, are filled in before the object is validated, so
+// that they count for keywords such as required. The then/else branch
+// of an allOf subschema fills its defaults when that subschema is
+// validated.
+//
+// NOTE: objects and arrays in v are modified in place. Default
+// values are copied before being filled in.
+func (sch *Schema) ApplyDefaults(v any) (any, error) {
+	eval := &evaluation{applyDefaults: true}
+	err := sch.validate(v, nil, nil, nil, false, nil, eval)
+	return eval.instance, err
+}
+
+func (vd *validator) applyingDefaults() bool {
+	return vd.eval.applyDefaults && !vd.boolResult && !vd.speculative
+}
+
+func (vd *validator) objDefaults(obj map[string]any) {
+	s := vd.sch
+	visited := map[*Schema]struct{}{}
+	fillDefaults(obj, s, visited)
+
+	// the branch is chosen before filling its defaults, and
+	// the choice is reused by condValidate, so that the
+	// defaults filled in do not change it
+	if s.If != nil && (s.Then != nil || s.Else != nil) {
+		valid := vd.validateSelf(s.If, "", true) == nil
+		vd.ifValid = &valid
+		branch := s.Else
+		if valid {
+			branch = s.Then
+		}
+		if branch != nil {
+			fillDefaults(obj, branch, visited)
+		}
+	}
+}
+
+// fillDefaults sets the missing properties of obj to the default
+// of their schema in sch, and in the $ref and allOf subschemas of sch.
+func fillDefaults(obj map[string]any, sch *Schema, visited map[*Schema]struct{}) {
+	if _, ok := visited[sch]; ok {
+		return
+	}
+	visited[sch] = struct{}{}
+	for pname, psch := range sch.Properties {
+		if _, ok := obj[pname]; ok {
+			continue
+		}
+		if def := psch.defaultValue(); def != nil {
+			obj[pname] = deepCopy(*def)
+		}
+	}
+	if sch.Ref != nil {
+		fillDefaults(obj, sch.Ref, visited)
+	}
+	for _, sub := range sch.AllOf {
+		fillDefaults(obj, sub, visited)
+	}
+}
+
+func (vd *validator) arrDefaults(arr []any) []any {
+	s := vd.sch
+	prefixItems := s.PrefixItems
+	if s.DraftVersion < 2020 {
+		prefixItems, _ = s.Items.([]*Schema)
+	}
+	n := len(arr)
+	for _, sch := range prefixItems[minInt(n, len(prefixItems)):] {
+		def := sch.defaultValue()
+		if def == nil {
+			break
+		}
+		arr = append(arr, deepCopy(*def))
+	}
+	if len(arr) != n {
+		vd.v, vd.replaced = arr, true
+	}
+	return arr
+}
+
+// defaultValue returns the default of sch. If sch has no
+// default, it is looked up in $ref and allOf subschemas.
+func (sch *Schema) defaultValue() *any {
+	return sch.findDefault(map[*Schema]struct{}{})
+}
+
+func (sch *Schema) findDefault(visited map[*Schema]struct{}) *any {
+	if _, ok := visited[sch]; ok {
+		return nil
+	}
+	visited[sch] = struct{}{}
+	if sch.Default != nil {
+		return sch.Default
+	}
+	if sch.Ref != nil {
+		if def := sch.Ref.findDefault(visited); def != nil {
+			return def
+		}
+	}
+	for _, sch := range sch.AllOf {
+		if def := sch.findDefault(visited); def != nil {
+			return def
+		}
+	}
+	return nil
+}
+
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		obj := make(map[string]any, len(v))
+		for pname, pvalue := range v {
+			obj[pname] = deepCopy(pvalue)
+		}
+		return obj
+	case []any:
+		arr := make([]any, len(v))
+		for i, item := range v {
+			arr[i] = deepCopy(item)
+		}
+		return arr
+	default:
+		return v
+	}
+}
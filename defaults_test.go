@@ -0,0 +1,88 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	sch := compileString(t, `{
+		"$defs": {
+			"port": { "type": "integer", "default": 8080 }
+		},
+		"type": "object",
+		"properties": {
+			"host": { "type": "string", "default": "localhost" },
+			"port": { "$ref": "#/$defs/port" },
+			"tls": {
+				"type": "object",
+				"default": {},
+				"properties": {
+					"enabled": { "type": "boolean", "default": false }
+				}
+			},
+			"mode": { "enum": ["dev", "prod"] },
+			"point": {
+				"type": "array",
+				"prefixItems": [
+					{ "type": "number", "default": 0 },
+					{ "type": "number", "default": 0 }
+				]
+			}
+		},
+		"required": ["host"],
+		"allOf": [
+			{ "properties": { "user": { "default": "admin" } } }
+		],
+		"if": { "properties": { "mode": { "const": "prod" } }, "required": ["mode"] },
+		"then": { "properties": { "workers": { "default": 8 } } },
+		"else": { "properties": { "workers": { "default": 1 } } },
+		"anyOf": [
+			{ "properties": { "debug": { "default": true } } }
+		]
+	}`)
+	inst := unmarshalString(t, `{"mode": "prod", "point": [1]}`)
+
+	got, err := sch.ApplyDefaults(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"host":"localhost","mode":"prod","point":[1,0],"port":8080,"tls":{"enabled":false},"user":"admin","workers":8}`
+	if b, _ := json.Marshal(got); string(b) != want {
+		t.Errorf("got %s\nwant %s", b, want)
+	}
+
+	// invalid instance
+	if _, err := sch.ApplyDefaults(unmarshalString(t, `{"host": 1}`)); err == nil {
+		t.Error("validation must fail")
+	}
+}
+
+func TestApplyDefaultsRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"allOf", `{"required": ["a"], "allOf": [{"properties": {"a": {"default": 1}}}]}`, `{"a":1}`},
+		{"ref", `{"required": ["a"], "$ref": "#/$defs/a", "$defs": {"a": {"properties": {"a": {"default": 1}}}}}`, `{"a":1}`},
+		{"then", `{
+			"required": ["a"],
+			"if": {"required": ["a"]},
+			"then": {"properties": {"b": {"default": 2}}},
+			"else": {"properties": {"a": {"default": 1}}}
+		}`, `{"a":1}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sch := compileString(t, test.schema)
+			got, err := sch.ApplyDefaults(map[string]any{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b, _ := json.Marshal(got); string(b) != test.want {
+				t.Errorf("got %s, want %s", b, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package jsonschema
+
+import "fmt"
+
+// DereferenceOptions controls how references are
+// inlined by [Schema.Dereference].
+type DereferenceOptions struct {
+	// MaxDepth is the number of times a schema may be nested
+	// within itself through recursive references. Beyond that,
+	// the subschema holding the recursive reference is replaced
+	// with the schema true, dropping its other keywords such as
+	// type and properties. So the dereferenced schema accepts
+	// more than the original at that depth. Zero means recursive
+	// reference is an error.
+	MaxDepth int
+
+	// DynamicScope lists the schemas, outermost first, which
+	// make the dynamic scope in which the schema is evaluated.
+	// It is used along with the schemas on the path being
+	// inlined, to resolve $dynamicRef and $recursiveRef.
+	DynamicScope []*Schema
+}
+
+// Dereference returns json schema document equivalent to s, without
+// any references. It is like [Schema.Document], but the referred
+// schemas are inlined. If the schema with reference has other
+// keywords, the referred schema is inlined as an item of allOf.
+//
+// $dynamicRef and $recursiveRef are resolved against dynamic scope
+// at the location they are inlined. Core keywords such as $id,
+// $anchor and $dynamicAnchor are not emitted, because they are of
+// no use without references, and may become duplicates on inlining.
+//
+// The document uses keywords of the latest draft among the inlined
+// schemas, and "$schema" of that draft. For example, exclusiveMaximum
+// of draft-04 is emitted as number, and items array as prefixItems,
+// if s is draft 2020-12 schema referring to draft-04 schema.
+//
+// If s refers to itself recursively, *RecursiveRefError is returned
+// unless opts.MaxDepth allows recursion. The subschema with recursive
+// reference beyond opts.MaxDepth is replaced with the schema true.
+func (s *Schema) Dereference(opts DereferenceOptions) (any, error) {
+	m := &marshaller{
+		root:    s,
+		emitted: map[*Schema]bool{},
+		deref:   &opts,
+		draft:   derefDraft(append([]*Schema{s}, opts.DynamicScope...)),
+		nested:  map[*Schema]int{},
+		scope:   append([]*Schema(nil), opts.DynamicScope...),
+	}
+	doc := m.value(s)
+	if m.err != nil {
+		return nil, m.err
+	}
+	if obj, ok := doc.(map[string]any); ok {
+		if d := draftFromVersion(m.draft); d != nil {
+			obj["$schema"] = d.url
+		}
+	}
+	return doc, nil
+}
+
+// derefDraft returns the latest draft version among the
+// schemas reachable from list.
+func derefDraft(list []*Schema) int {
+	version := 0
+	visited := map[*Schema]bool{}
+	for len(list) > 0 {
+		s := list[len(list)-1]
+		list = list[:len(list)-1]
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		version = max(version, s.DraftVersion)
+		list = append(list, s.linkedSchemas()...)
+	}
+	return version
+}
+
+// translateKeywords replaces keywords in obj, which are not
+// part of given draft version, with their equivalents.
+// draft version is never older than that of obj.
+func translateKeywords(obj map[string]any, version int) {
+	if version >= 2019 {
+		if deps, ok := obj["dependencies"].(map[string]any); ok {
+			delete(obj, "dependencies")
+			for pname, dep := range deps {
+				kw := "dependentSchemas"
+				if _, ok := dep.([]any); ok {
+					kw = "dependentRequired"
+				}
+				m, ok := obj[kw].(map[string]any)
+				if !ok {
+					m = map[string]any{}
+					obj[kw] = m
+				}
+				m[pname] = dep
+			}
+		}
+	}
+	if version >= 2020 {
+		if items, ok := obj["items"].([]any); ok {
+			obj["prefixItems"] = items
+			delete(obj, "items")
+			if v, ok := obj["additionalItems"]; ok {
+				obj["items"] = v
+				delete(obj, "additionalItems")
+			}
+		} else {
+			// additionalItems is ignored, if items is not array
+			delete(obj, "additionalItems")
+		}
+	}
+}
+
+// inlineRefs returns obj, the json value of sch without core
+// keywords, with the references of sch inlined.
+func (m *marshaller) inlineRefs(sch *Schema, obj map[string]any) any {
+	var refs []any
+	cut := false
+	add := func(ref *Schema) {
+		v, ok := m.inline(ref)
+		cut = cut || !ok
+		refs = append(refs, v)
+	}
+	if sch.Ref != nil {
+		add(sch.Ref)
+		if sch.DraftVersion < 2019 {
+			// all other properties in "$ref" object are ignored
+			return refs[0]
+		}
+	}
+	if ref := sch.RecursiveRef; ref != nil {
+		if ref.RecursiveAnchor {
+			for _, s := range m.scope {
+				if s.resource.RecursiveAnchor {
+					ref = s.resource
+					break
+				}
+			}
+		}
+		add(ref)
+	}
+	if dref := sch.DynamicRef; dref != nil {
+		ref := dref.Ref
+		if dref.Anchor != "" && ref.DynamicAnchor == dref.Anchor {
+			for _, s := range m.scope {
+				if dsch, ok := s.resource.dynamicAnchors[dref.Anchor]; ok {
+					ref = dsch
+					break
+				}
+			}
+		}
+		add(ref)
+	}
+
+	if cut {
+		// other keywords such as unevaluatedProperties
+		// may depend on the annotations of referred schema
+		return boolValue(true, m.draft)
+	}
+	if len(refs) == 1 && len(obj) == 0 {
+		return refs[0]
+	}
+	if len(refs) > 0 {
+		allOf, _ := obj["allOf"].([]any)
+		obj["allOf"] = append(allOf, refs...)
+	}
+	return obj
+}
+
+// inline returns json value of referred schema sch.
+// It returns false, if recursion is cut off at sch.
+func (m *marshaller) inline(sch *Schema) (any, bool) {
+	if n := m.nested[sch]; n > 0 {
+		if m.deref.MaxDepth == 0 {
+			if m.err == nil {
+				m.err = &RecursiveRefError{Location: sch.Location}
+			}
+			return true, false
+		}
+		if n > m.deref.MaxDepth {
+			return boolValue(true, m.draft), false
+		}
+	}
+	return m.value(sch), true
+}
+
+// RecursiveRefError is returned by [Schema.Dereference], if
+// schema refers to itself and recursion is not allowed.
+type RecursiveRefError struct {
+	// Location is the schema referred recursively.
+	Location string
+}
+
+func (e *RecursiveRefError) Error() string {
+	return fmt.Sprintf("recursive reference to %q", e.Location)
+}
@@ -0,0 +1,223 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestSchemaDereference(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/common.json", unmarshalString(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"definitions": {
+			"zip": { "type": "string", "pattern": "^[0-9]{5}$" }
+		}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/person.json", unmarshalString(t, `{
+		"$id": "http://example.com/person.json",
+		"type": "object",
+		"properties": {
+			"name": { "$ref": "#/$defs/name" },
+			"nick": { "$ref": "#/$defs/name", "maxLength": 5 },
+			"zip": { "$ref": "common.json#/definitions/zip" }
+		},
+		"$defs": {
+			"name": { "$anchor": "name", "type": "string", "minLength": 1 }
+		}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("http://example.com/person.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := sch.Dereference(jsonschema.DereferenceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": { "type": "string", "minLength": 1 },
+			"nick": { "maxLength": 5, "allOf": [{ "type": "string", "minLength": 1 }] },
+			"zip": { "type": "string", "pattern": "^[0-9]{5}$" }
+		}
+	}`
+	if !jsonEqual(t, doc, unmarshalString(t, want)) {
+		b, _ := json.Marshal(doc)
+		t.Fatalf("got %s", b)
+	}
+}
+
+func TestSchemaDereferenceRecursive(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("tree.json", unmarshalString(t, `{
+		"type": "object",
+		"properties": {
+			"value": { "type": "integer" },
+			"children": { "type": "array", "items": { "$ref": "#" } }
+		}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("tree.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sch.Dereference(jsonschema.DereferenceOptions{})
+	var rerr *jsonschema.RecursiveRefError
+	if !errors.As(err, &rerr) || rerr.Location != sch.Location {
+		t.Fatalf("got %v, want RecursiveRefError", err)
+	}
+
+	doc, err := sch.Dereference(jsonschema.DereferenceOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"value": { "type": "integer" },
+			"children": { "type": "array", "items": {
+				"type": "object",
+				"properties": {
+					"value": { "type": "integer" },
+					"children": { "type": "array", "items": true }
+				}
+			}}
+		}
+	}`
+	if !jsonEqual(t, doc, unmarshalString(t, want)) {
+		b, _ := json.Marshal(doc)
+		t.Fatalf("got %s", b)
+	}
+}
+
+func TestSchemaDereferenceDynamicRef(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/tree.json", unmarshalString(t, `{
+		"$id": "http://example.com/tree.json",
+		"$dynamicAnchor": "node",
+		"type": "object",
+		"properties": {
+			"children": { "type": "array", "items": { "$dynamicRef": "#node" } }
+		}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/strict-tree.json", unmarshalString(t, `{
+		"$id": "http://example.com/strict-tree.json",
+		"$dynamicAnchor": "node",
+		"$ref": "tree.json",
+		"unevaluatedProperties": false
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := c.Compile("http://example.com/tree.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	strictTree, err := c.Compile("http://example.com/strict-tree.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// node returns tree schema, whose children are items
+	node := func(items string) string {
+		return `{
+			"type": "object",
+			"properties": {
+				"children": { "type": "array", "items": ` + items + ` }
+			}
+		}`
+	}
+	strictNode := func(items string) string {
+		return `{ "unevaluatedProperties": false, "allOf": [` + node(items) + `] }`
+	}
+	tests := []struct {
+		name  string
+		sch   *jsonschema.Schema
+		scope []*jsonschema.Schema
+		want  string
+	}{
+		{"tree", tree, nil, node(node("true"))},
+		{"strictTree", strictTree, nil, strictNode(strictNode("true"))},
+		{"treeInStrictScope", tree, []*jsonschema.Schema{strictTree}, node(strictNode("true"))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := test.sch.Dereference(jsonschema.DereferenceOptions{MaxDepth: 1, DynamicScope: test.scope})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := unmarshalString(t, test.want).(map[string]any)
+			want["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+			if !jsonEqual(t, doc, want) {
+				b, _ := json.Marshal(doc)
+				t.Fatalf("got %s", b)
+			}
+		})
+	}
+}
+
+func TestSchemaDereferenceMixedDrafts(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/draft4.json", unmarshalString(t, `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"maximum": 5,
+		"exclusiveMaximum": true,
+		"items": [{ "type": "integer" }],
+		"additionalItems": false,
+		"dependencies": { "a": ["b"], "c": { "required": ["d"] } }
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/root.json", unmarshalString(t, `{
+		"$ref": "draft4.json"
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("http://example.com/root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := sch.Dereference(jsonschema.DereferenceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"exclusiveMaximum": 5,
+		"prefixItems": [{ "type": "integer" }],
+		"items": false,
+		"dependentRequired": { "a": ["b"] },
+		"dependentSchemas": { "c": { "required": ["d"] } }
+	}`
+	if !jsonEqual(t, doc, unmarshalString(t, want)) {
+		b, _ := json.Marshal(doc)
+		t.Fatalf("got %s", b)
+	}
+
+	c = jsonschema.NewCompiler()
+	if err := c.AddResource("deref.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	dsch, err := c.Compile("deref.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, inst := range []string{`5`, `4`, `[1]`, `[1, 2]`, `["x"]`, `{"a": 1}`, `{"c": 1, "d": 2}`} {
+		v := unmarshalString(t, inst)
+		if got, want := dsch.Validate(v) == nil, sch.Validate(v) == nil; got != want {
+			t.Errorf("%s: valid=%v, want %v", inst, got, want)
+		}
+	}
+}
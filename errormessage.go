@@ -0,0 +1,203 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// ErrorMessageVocabURL is the url of vocabulary returned by [ErrorMessageVocab].
+const ErrorMessageVocabURL = "https://github.com/liuxd6825/jsonschema/vocab/error-message"
+
+// ErrorMessageVocab returns the vocabulary that introduces keyword
+// errorMessage, to replace the default error messages of a schema:
+//
+//   - string: replaces all errors of the schema, including the errors
+//     of its subschemas, with single error with the given message.
+//   - object: maps keyword to the message of its errors, ex:
+//     {"minLength": "too short"}. Key "properties" maps property name
+//     to the message of errors of that property. Key "_" gives message
+//     for the remaining errors reported by keywords of the schema.
+//
+// Messages can have placeholders: {{value}} for instance value,
+// {{keyword}} for the failed keyword and {{param}} for each param
+// of the error as in [ErrorInfo], ex: {{got}}, {{want}}. Strings
+// are substituted as they are, other values as json.
+//
+// Register it using [Compiler.RegisterVocabulary]. Note that for
+// draft >= 2019-09 vocabularies are disabled, unless enabled via
+// $vocabulary in metaschema or by [Compiler.AssertVocabs].
+func ErrorMessageVocab() *Vocabulary {
+	schema, err := UnmarshalJSON(strings.NewReader(`{
+		"properties": {
+			"errorMessage": {
+				"type": ["string", "object"],
+				"properties": {
+					"properties": {
+						"type": "object",
+						"additionalProperties": { "type": "string" }
+					}
+				},
+				"additionalProperties": { "type": "string" }
+			}
+		}
+	}`))
+	if err != nil {
+		panic(err)
+	}
+
+	c := NewCompiler()
+	if err := c.AddResource(ErrorMessageVocabURL, schema); err != nil {
+		panic(err)
+	}
+
+	return &Vocabulary{
+		URL:     ErrorMessageVocabURL,
+		Schema:  c.MustCompile(ErrorMessageVocabURL),
+		Compile: compileErrorMessages,
+	}
+}
+
+func compileErrorMessages(ctx *CompilerContext, obj map[string]any) (SchemaExt, error) {
+	switch v := obj["errorMessage"].(type) {
+	case string:
+		return &errorMessages{all: v}, nil
+	case map[string]any:
+		m := &errorMessages{keywords: map[string]string{}}
+		for kw, msg := range v {
+			if kw == "properties" {
+				m.properties = map[string]string{}
+				for pname, msg := range msg.(map[string]any) {
+					m.properties[pname] = msg.(string)
+				}
+			} else {
+				m.keywords[kw] = msg.(string)
+			}
+		}
+		return m, nil
+	}
+	return nil, nil
+}
+
+type errorMessages struct {
+	all        string
+	keywords   map[string]string // "_" for remaining keywords
+	properties map[string]string
+}
+
+// adjust makes s report errors with messages m.
+func (m *errorMessages) adjust(s *Schema) {
+	s.errorMessages = m
+}
+
+func (m *errorMessages) marshalKeywords() map[string]any {
+	if m.keywords == nil {
+		return map[string]any{"errorMessage": m.all}
+	}
+	obj := map[string]any{}
+	for kw, msg := range m.keywords {
+		obj[kw] = msg
+	}
+	if m.properties != nil {
+		props := map[string]any{}
+		for pname, msg := range m.properties {
+			props[pname] = msg
+		}
+		obj["properties"] = props
+	}
+	return map[string]any{"errorMessage": obj}
+}
+
+// messages are applied by validator, after the schema is evaluated.
+func (m *errorMessages) Validate(ctx *ValidatorContext, v any) {}
+
+// apply sets custom messages to the errors in verr, which
+// is the result of validating vd, and returns the result.
+func (m *errorMessages) apply(vd *validator, verr *ValidationError) *ValidationError {
+	if vd.boolResult {
+		return verr
+	}
+	errors := []*ValidationError{verr}
+	if _, ok := verr.ErrorKind.(*kind.Group); ok && verr.SchemaURL == vd.sch.Location {
+		errors = verr.Causes
+	}
+	if m.all != "" {
+		err := errors[0]
+		if len(errors) > 1 || err.SchemaURL != vd.sch.Location || !slices.Equal(err.InstanceLocation, vd.vloc) {
+			err = vd.error(&kind.Group{})
+		}
+		err.Causes = nil
+		err.Message = expandErrorMessage(m.all, err.ErrorKind, vd.v)
+		return err
+	}
+	for _, err := range errors {
+		if err.ErrorKind == nil {
+			continue
+		}
+		if len(err.InstanceLocation) == len(vd.vloc)+1 && slices.Equal(err.InstanceLocation[:len(vd.vloc)], vd.vloc) {
+			// error of property value
+			pname := err.InstanceLocation[len(vd.vloc)]
+			if msg, ok := m.properties[pname]; ok {
+				var pvalue any
+				if obj, ok := vd.v.(map[string]any); ok {
+					pvalue = obj[pname]
+				}
+				err.Causes = nil
+				err.Message = expandErrorMessage(msg, err.ErrorKind, pvalue)
+			}
+			continue
+		}
+		if err.SchemaURL != vd.sch.Location || isGroupKind(err.ErrorKind) {
+			continue
+		}
+		msg, ok := m.keywords[errorKeyword(err.ErrorKind)]
+		if !ok {
+			msg, ok = m.keywords["_"]
+		}
+		if ok {
+			err.Message = expandErrorMessage(msg, err.ErrorKind, vd.v)
+		}
+	}
+	return verr
+}
+
+var placeholderRegexp = regexp.MustCompile(`{{\s*([a-zA-Z]+)\s*}}`)
+
+// expandErrorMessage replaces placeholders in msg.
+func expandErrorMessage(msg string, k ErrorKind, v any) string {
+	var params map[string]any
+	return placeholderRegexp.ReplaceAllStringFunc(msg, func(s string) string {
+		name := placeholderRegexp.FindStringSubmatch(s)[1]
+		switch name {
+		case "value":
+			return placeholderString(v)
+		case "keyword":
+			return errorKeyword(k)
+		}
+		if params == nil {
+			params = NewErrorInfo(k).Params
+		}
+		if p, ok := params[name]; ok {
+			return placeholderString(p)
+		}
+		return s
+	})
+}
+
+func placeholderString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
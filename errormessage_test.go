@@ -0,0 +1,137 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func compileErrorMessage(t *testing.T, schema string) *jsonschema.Schema {
+	t.Helper()
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertVocabs()
+	c.RegisterVocabulary(jsonschema.ErrorMessageVocab())
+	if err := c.AddResource("schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sch
+}
+
+func TestErrorMessageVocab(t *testing.T) {
+	tests := []struct {
+		schema string
+		v      string
+		want   []string // leaf messages in basic output
+	}{
+		{
+			`{"type": "string", "minLength": 3, "errorMessage": {"minLength": "need {{want}} chars, got {{got}} in '{{value}}'"}}`,
+			`"ab"`,
+			[]string{"need 3 chars, got 2 in 'ab'"},
+		},
+		{
+			`{"type": "string", "minLength": 3, "errorMessage": {"_": "bad {{keyword}}"}}`,
+			`1`,
+			[]string{"bad type"},
+		},
+		{
+			`{"type": "string", "minLength": 3, "errorMessage": {"maxLength": "ignored"}}`,
+			`"ab"`,
+			[]string{"minLength: got 2, want 3"},
+		},
+		{
+			`{
+				"properties": {
+					"name": { "type": "string", "minLength": 3, "pattern": "^[a-z]+$" },
+					"age": { "minimum": 0 }
+				},
+				"required": ["id"],
+				"errorMessage": {
+					"required": "id is mandatory",
+					"properties": { "name": "name must be 3+ lowercase letters, got {{value}}" }
+				}
+			}`,
+			`{"name": "AB", "age": -1}`,
+			[]string{"id is mandatory", "name must be 3+ lowercase letters, got AB", "minimum: got -1, want 0"},
+		},
+		{
+			`{"properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "errorMessage": "invalid value {{value}}"}`,
+			`{"a": 1, "b": 2}`,
+			[]string{`invalid value {"a":1,"b":2}`},
+		},
+	}
+	for i, test := range tests {
+		sch := compileErrorMessage(t, test.schema)
+		v, err := jsonschema.UnmarshalJSON(strings.NewReader(test.v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = sch.Validate(v)
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			t.Fatalf("%d: got %v, want *ValidationError", i, err)
+		}
+		var got []string
+		for _, unit := range verr.BasicOutput().Errors {
+			if unit.Error != nil {
+				b, _ := json.Marshal(unit.Error)
+				var msg string
+				_ = json.Unmarshal(b, &msg)
+				got = append(got, msg)
+			}
+		}
+		slices.Sort(got) // properties are validated in random order
+		want := slices.Clone(test.want)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("%d: got %q, want %q", i, got, test.want)
+		}
+		for _, msg := range test.want {
+			if !strings.Contains(verr.Error(), msg) {
+				t.Errorf("%d: Error() must contain %q:\n%v", i, msg, verr)
+			}
+		}
+	}
+}
+
+func TestErrorMessageVocab_invalid(t *testing.T) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(`{"errorMessage": {"minLength": 1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertVocabs()
+	c.RegisterVocabulary(jsonschema.ErrorMessageVocab())
+	if err := c.AddResource("schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Compile("schema.json"); err == nil {
+		t.Fatal("non-string message must fail")
+	}
+}
+
+func TestErrorMessageVocab_ref(t *testing.T) {
+	sch := compileErrorMessage(t, `{
+		"$ref": "#/$defs/pair",
+		"$defs": {
+			"pair": {
+				"properties": {"a": {"type": "string"}, "b": {"type": "string"}},
+				"errorMessage": "not a pair"
+			}
+		}
+	}`)
+	err := sch.Validate(map[string]any{"a": 1.0, "b": 2.0})
+	if err == nil || !strings.Contains(err.Error(), "not a pair") {
+		t.Fatalf("got %v, want error with custom message", err)
+	}
+}
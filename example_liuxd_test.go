@@ -87,12 +87,18 @@ func Test_DateType2(t *testing.T) {
 		log.Fatalf("编译失败: %v", err)
 	}
 	inst := map[string]any{
-		"birthday": "time.Now()",
+		"birthday": "2024-02-29",
 	}
 	err = sch.Validate(inst)
 	if err != nil {
 		t.Error(err)
 	}
+	inst = map[string]any{
+		"birthday": "time.Now()",
+	}
+	if err = sch.Validate(inst); err == nil {
+		t.Error("string which is not RFC 3339 full-date must fail")
+	}
 }
 
 func Test_OneOf(t *testing.T) {
@@ -121,10 +127,16 @@ func Test_OneOf(t *testing.T) {
 		log.Fatalf("编译失败: %v", err)
 	}
 	inst := map[string]any{
-		"birthday": nil,
+		"birthday": time.Now(),
 	}
 	err = sch.Validate(inst)
 	if err != nil {
 		t.Error(err)
 	}
+	inst = map[string]any{
+		"birthday": nil,
+	}
+	if err = sch.Validate(inst); err == nil {
+		t.Error("null must fail for type [\"date\"]")
+	}
 }
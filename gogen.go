@@ -0,0 +1,641 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	gourl "net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateGo returns go source of package pkg, declaring types for
+// values valid against sch. The type for sch is named typeName. If
+// typeName is empty, it is derived from title of sch or else from its
+// location.
+//
+// The type mapping is:
+//
+//   - "object" with properties is struct. Fields have json tags, and
+//     fields that are not required have omitempty. allOf subschemas with
+//     properties are embedded if named, else their fields are merged.
+//   - "object" without properties is map.
+//   - "array" is slice.
+//   - "string" is string; [time.Time] with type "datetime" or format
+//     "date-time", []byte with contentEncoding "base64".
+//   - "integer" is int64, "number" is float64, "boolean" is bool.
+//   - enum of only strings, or only integers, is a named type with
+//     constants for each value.
+//   - oneOf or anyOf with more than one alternative, excluding null, is
+//     sealed interface, implemented by a named type for each alternative,
+//     which is named after its title if any.
+//     A function UnmarshalXXX is generated, which decodes into the first
+//     alternative that decodes without unknown fields. Structs with
+//     fields of such interface, or of its slice or map, get UnmarshalJSON
+//     method which uses it.
+//   - anything else is any.
+//
+// Fields that are nullable or not required are pointers, unless they
+// are slices, maps or interfaces. Schemas in $defs or definitions, and
+// schemas referring to themselves, are named types. Other keywords are
+// not reflected in generated source.
+//
+// The source begins with "Code generated ... DO NOT EDIT." comment,
+// naming the location of sch, or its file name for local files.
+func GenerateGo(sch *Schema, pkg, typeName string) ([]byte, error) {
+	if typeName == "" {
+		typeName = sch.Title
+	}
+	u, _, _ := strings.Cut(sch.Location, "#")
+	if typeName == "" {
+		typeName = strings.TrimSuffix(path.Base(u), path.Ext(u))
+	}
+	typeName = goName(typeName)
+	if typeName == "" {
+		typeName = "Schema"
+	}
+
+	g := &goGen{
+		root:         sch,
+		rootName:     typeName,
+		names:        map[*Schema]string{},
+		used:         map[string]bool{},
+		methodable:   map[string]bool{},
+		interfaces:   map[string]string{},
+		imports:      map[string]bool{},
+		declaring:    map[string]bool{},
+		unmarshalers: map[string]bool{},
+		recursive:    recursiveSchemas(sch),
+		nilable:      map[string]bool{},
+	}
+	g.goType(sch, typeName)
+
+	var sb strings.Builder
+	src := sch.Location
+	if strings.HasPrefix(src, "file://") {
+		src = path.Base(u) // avoid machine specific path
+	}
+	fmt.Fprintf(&sb, "// Code generated from %s. DO NOT EDIT.\n\n", src)
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		var imports []string
+		for imp := range g.imports {
+			imports = append(imports, strconv.Quote(imp))
+		}
+		slices.Sort(imports)
+		fmt.Fprintf(&sb, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	for _, decl := range g.decls {
+		sb.WriteString(decl)
+		sb.WriteString("\n")
+	}
+	return format.Source([]byte(sb.String()))
+}
+
+type goGen struct {
+	root         *Schema
+	rootName     string
+	names        map[*Schema]string // declared types
+	used         map[string]bool    // declared identifiers
+	methodable   map[string]bool    // declared types which can have methods
+	interfaces   map[string]string  // declared sealed interfaces, to their unmarshal function
+	imports      map[string]bool
+	declaring    map[string]bool  // types whose declaration is in progress
+	unmarshalers map[string]bool  // declared structs with UnmarshalJSON method
+	recursive    map[*Schema]bool // schemas that need named type to break recursion
+	nilable      map[string]bool  // declared slice and map types
+	decls        []string
+}
+
+// goType returns the go type for s, and whether it is nullable.
+// hint is used as type name, if s needs declaration.
+func (g *goGen) goType(s *Schema, hint string) (string, bool) {
+	s = derefSchema(s)
+	if s == nil || s.Bool != nil {
+		return "any", false
+	}
+	if alts, null := alternatives(s); len(alts) == 1 && !isStructLike(s) {
+		typ, _ := g.goType(alts[0], hint)
+		return typ, null
+	}
+	nullable := s.Types != nil && s.Types.contains(nullType) && *s.Types != Types(nullType)
+	if name, ok := g.names[s]; ok {
+		return name, nullable
+	}
+	name := g.defName(s)
+	if name == "" && (needsDecl(s) || g.recursive[s]) {
+		name = hint
+	}
+	if name != "" {
+		return g.declare(s, name), nullable
+	}
+	return g.expr(s, hint), nullable
+}
+
+// fieldType returns go type for field with schema s,
+// using pointer if it is optional.
+func (g *goGen) fieldType(s *Schema, hint string, optional bool) string {
+	typ, nullable := g.goType(s, hint)
+	pointer := nullable || optional || g.declaring[typ]
+	if pointer && g.needsPointer(typ) {
+		return "*" + typ
+	}
+	return typ
+}
+
+// needsPointer tells whether type typ needs pointer to be nullable.
+func (g *goGen) needsPointer(typ string) bool {
+	switch {
+	case typ == "any", strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return false
+	}
+	return g.interfaces[typ] == "" && !g.nilable[typ]
+}
+
+// defName returns the name for s, if it is root or in $defs.
+func (g *goGen) defName(s *Schema) string {
+	if s == g.root {
+		return g.rootName
+	}
+	_, frag, ok := strings.Cut(s.Location, "#")
+	if !ok {
+		return ""
+	}
+	toks := strings.Split(frag, "/")
+	if n := len(toks); n >= 2 && (toks[n-2] == "$defs" || toks[n-2] == "definitions") {
+		tok, err := gourl.PathUnescape(toks[n-1])
+		if err != nil {
+			return ""
+		}
+		if tok, ok := unescape(tok); ok {
+			return goName(tok)
+		}
+	}
+	return ""
+}
+
+// unique returns name, suffixed with number if already used.
+func (g *goGen) unique(name string) string {
+	result := name
+	for i := 2; g.used[result]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	g.used[result] = true
+	return result
+}
+
+// declare adds type declaration for s and returns its name.
+func (g *goGen) declare(s *Schema, name string) string {
+	name = g.unique(name)
+	g.names[s] = name
+	g.declaring[name] = true
+	defer delete(g.declaring, name)
+
+	i := len(g.decls)
+	g.decls = append(g.decls, "") // reserve to keep declaration order
+	var sb strings.Builder
+	writeTypeDoc(&sb, name, s)
+	switch alts, _ := alternatives(s); {
+	case len(alts) > 1 && !isStructLike(s):
+		g.sealedDecl(&sb, name, alts)
+	case enumType(s) != "":
+		g.enumDecl(&sb, s, name)
+	case isStructLike(s):
+		g.methodable[name] = true
+		g.structDecl(&sb, s, name)
+	default:
+		g.methodable[name] = true
+		if t := schemaTypes(s); t == Types(arrayType) || t == Types(objectType) {
+			g.nilable[name] = true // known before expr, which may refer to name
+		}
+		fmt.Fprintf(&sb, "type %s %s\n", name, g.expr(s, name))
+	}
+	g.decls[i] = sb.String()
+	return name
+}
+
+// sealedField is struct field, whose type is sealed interface,
+// or slice or map of it.
+type sealedField struct {
+	name      string // field name
+	tag       string // json name
+	container string // "", "[]" or "map[string]"
+	iface     string // interface type
+}
+
+func (g *goGen) structDecl(sb *strings.Builder, s *Schema, name string) {
+	var fields strings.Builder
+	var sealed []sealedField
+	used := map[string]bool{}
+	var addProps func(s *Schema)
+	addProps = func(s *Schema) {
+		for _, sub := range s.AllOf {
+			sub = derefSchema(sub)
+			if sub == nil || !isStructLike(sub) {
+				continue
+			}
+			if g.defName(sub) != "" {
+				typ, _ := g.goType(sub, "")
+				// promoted UnmarshalJSON would hide other fields
+				if !g.unmarshalers[typ] {
+					used[typ] = true
+					fmt.Fprintf(&fields, "%s\n", typ)
+					continue
+				}
+			}
+			addProps(sub)
+		}
+		for _, pname := range sortedProperties(s) {
+			psch := s.Properties[pname]
+			fname := goName(pname)
+			if fname == "" {
+				fname = "Field"
+			}
+			for i := 2; used[fname]; i++ {
+				fname = goName(pname) + strconv.Itoa(i)
+			}
+			used[fname] = true
+			required := slices.Contains(s.Required, pname)
+			typ := g.fieldType(psch, name+fname, !required)
+			tag := pname
+			if !required {
+				tag += ",omitempty"
+			}
+			if d := derefSchema(psch); g.defName(d) == "" {
+				writeDoc(&fields, d)
+			}
+			fmt.Fprintf(&fields, "%s %s `json:%q`\n", fname, typ, tag)
+			for _, container := range []string{"", "[]", "map[string]"} {
+				if iface, ok := strings.CutPrefix(typ, container); ok && g.interfaces[iface] != "" {
+					sealed = append(sealed, sealedField{fname, pname, container, iface})
+					break
+				}
+			}
+		}
+	}
+	addProps(s)
+	fmt.Fprintf(sb, "type %s struct {\n%s}\n", name, fields.String())
+	if len(sealed) > 0 {
+		g.unmarshalers[name] = true
+		g.unmarshalJSONDecl(sb, name, sealed)
+	}
+}
+
+// unmarshalJSONDecl writes UnmarshalJSON method for struct name,
+// which decodes the sealed fields using their unmarshal function.
+func (g *goGen) unmarshalJSONDecl(sb *strings.Builder, name string, sealed []sealedField) {
+	g.imports["encoding/json"] = true
+	fmt.Fprintf(sb, "\n// UnmarshalJSON implements json.Unmarshaler, decoding interface fields\n")
+	fmt.Fprintf(sb, "// into the type implementing them.\n")
+	fmt.Fprintf(sb, "func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(sb, "type plain %s\nvar aux struct {\n*plain\n", name)
+	for _, f := range sealed {
+		fmt.Fprintf(sb, "%s %sjson.RawMessage `json:%q`\n", f.name, f.container, f.tag)
+	}
+	fmt.Fprintf(sb, "}\naux.plain = (*plain)(v)\nif err := json.Unmarshal(data, &aux); err != nil {\nreturn err\n}\n")
+	fmt.Fprintf(sb, "var err error\n")
+	for _, f := range sealed {
+		unmarshal := g.interfaces[f.iface]
+		switch f.container {
+		case "":
+			fmt.Fprintf(sb, "if len(aux.%[1]s) > 0 && string(aux.%[1]s) != \"null\" {\nif v.%[1]s, err = %[2]s(aux.%[1]s); err != nil {\nreturn err\n}\n}\n", f.name, unmarshal)
+		case "[]":
+			fmt.Fprintf(sb, "if aux.%[1]s != nil {\nv.%[1]s = make([]%[3]s, len(aux.%[1]s))\nfor i, item := range aux.%[1]s {\nif v.%[1]s[i], err = %[2]s(item); err != nil {\nreturn err\n}\n}\n}\n", f.name, unmarshal, f.iface)
+		default:
+			fmt.Fprintf(sb, "if aux.%[1]s != nil {\nv.%[1]s = make(map[string]%[3]s, len(aux.%[1]s))\nfor k, item := range aux.%[1]s {\nif v.%[1]s[k], err = %[2]s(item); err != nil {\nreturn err\n}\n}\n}\n", f.name, unmarshal, f.iface)
+		}
+	}
+	fmt.Fprintf(sb, "return nil\n}\n")
+}
+
+func (g *goGen) enumDecl(sb *strings.Builder, s *Schema, name string) {
+	g.methodable[name] = true
+	fmt.Fprintf(sb, "type %s %s\n\nconst (\n", name, enumType(s))
+	used := map[string]bool{}
+	for i, v := range s.Enum.Values {
+		var cname, lit string
+		if str, ok := v.(string); ok {
+			cname, lit = name+goName(str), strconv.Quote(str)
+		} else {
+			lit = fmt.Sprint(v)
+			cname = name + strings.NewReplacer("-", "Minus", "+", "", ".", "_").Replace(lit)
+		}
+		if cname == name || used[cname] || g.used[cname] {
+			cname = name + strconv.Itoa(i+1)
+		}
+		used[cname] = true
+		g.used[cname] = true
+		fmt.Fprintf(sb, "%s %s = %s\n", cname, name, lit)
+	}
+	sb.WriteString(")\n")
+}
+
+func (g *goGen) sealedDecl(sb *strings.Builder, name string, alts []*Schema) {
+	fname := g.unique("Unmarshal" + name)
+	g.interfaces[name] = fname
+	marker := "is" + name
+	fmt.Fprintf(sb, "type %s interface {\n%s()\n}\n", name, marker)
+
+	var variants []string
+	for i, alt := range alts {
+		hint := name + strconv.Itoa(i+1)
+		if d := derefSchema(alt); d != nil && goName(d.Title) != "" {
+			hint = goName(d.Title)
+		}
+		typ, _ := g.goType(alt, hint)
+		if !g.methodable[typ] {
+			wrapper := g.unique(hint)
+			g.methodable[wrapper] = true
+			fmt.Fprintf(sb, "\ntype %s %s\n", wrapper, typ)
+			typ = wrapper
+		}
+		if !slices.Contains(variants, typ) {
+			variants = append(variants, typ)
+		}
+	}
+	for _, v := range variants {
+		fmt.Fprintf(sb, "\nfunc (%s) %s() {}\n", v, marker)
+	}
+
+	g.imports["bytes"] = true
+	g.imports["encoding/json"] = true
+	g.imports["errors"] = true
+	fmt.Fprintf(sb, "\n// %s decodes data into the first type implementing %s,\n", fname, name)
+	fmt.Fprintf(sb, "// that decodes without unknown fields.\n")
+	fmt.Fprintf(sb, "func %s(data []byte) (%s, error) {\n", fname, name)
+	for _, v := range variants {
+		fmt.Fprintf(sb, "{\nvar v %s\ndec := json.NewDecoder(bytes.NewReader(data))\ndec.DisallowUnknownFields()\nif err := dec.Decode(&v); err == nil {\nreturn v, nil\n}\n}\n", v)
+	}
+	fmt.Fprintf(sb, "return nil, errors.New(%q)\n}\n", "no type implementing "+name+" matches")
+}
+
+// expr returns go type expression for s.
+func (g *goGen) expr(s *Schema, hint string) string {
+	types := schemaTypes(s)
+	switch {
+	case types == Types(objectType):
+		if ap, ok := s.AdditionalProperties.(*Schema); ok {
+			return "map[string]" + g.fieldType(ap, hint+"Value", false)
+		}
+		return "map[string]any"
+	case types == Types(arrayType):
+		var items *Schema
+		if s.Items2020 != nil && len(s.PrefixItems) == 0 {
+			items = s.Items2020
+		} else if sch, ok := s.Items.(*Schema); ok {
+			items = sch
+		}
+		if items == nil {
+			return "[]any"
+		}
+		return "[]" + g.fieldType(items, hint+"Item", false)
+	case types == Types(stringType):
+		if s.formatName == "date-time" {
+			g.imports["time"] = true
+			return "time.Time"
+		}
+		if s.ContentEncoding != nil && s.ContentEncoding.Name == "base64" {
+			return "[]byte"
+		}
+		return "string"
+	case types == Types(dateTimeType):
+		g.imports["time"] = true
+		return "time.Time"
+	case types == Types(dateType):
+		return "string"
+	case types == Types(integerType):
+		return "int64"
+	case types == Types(numberType), types == Types(numberType|integerType):
+		return "float64"
+	case types == Types(booleanType):
+		return "bool"
+	}
+	return "any"
+}
+
+// writeDoc writes title and description of s as comment.
+func writeDoc(sb *strings.Builder, s *Schema) {
+	if s == nil {
+		return
+	}
+	for _, text := range []string{s.Title, s.Description} {
+		if text == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+			fmt.Fprintf(sb, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+}
+
+// writeTypeDoc writes doc comment of type name declared for s.
+// Title is written as "name is title.", unless name is derived from it.
+func writeTypeDoc(sb *strings.Builder, name string, s *Schema) {
+	title := strings.Join(strings.Fields(s.Title), " ")
+	if title != "" && strings.TrimRight(name, "0123456789") != goName(title) {
+		fmt.Fprintf(sb, "// %s is %s.\n", name, strings.TrimSuffix(title, "."))
+	}
+	if s.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(s.Description), "\n") {
+			fmt.Fprintf(sb, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+}
+
+// recursiveSchemas returns the schemas reachable from s, which
+// are reachable from themselves through the subschemas that
+// goType follows. Giving them named type ends the recursion.
+func recursiveSchemas(s *Schema) map[*Schema]bool {
+	recursive := map[*Schema]bool{}
+	state := map[*Schema]int{} // 1: visiting, 2: visited
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		s = unwrapSchema(s)
+		if s == nil {
+			return
+		}
+		switch state[s] {
+		case 1:
+			recursive[s] = true
+			return
+		case 2:
+			return
+		}
+		state[s] = 1
+		list := append([]*Schema{s.Items2020}, s.AllOf...)
+		list = append(list, s.OneOf...)
+		list = append(list, s.AnyOf...)
+		list = append(list, s.PrefixItems...)
+		for _, pname := range sortedProperties(s) {
+			list = append(list, s.Properties[pname])
+		}
+		for _, v := range []any{s.AdditionalProperties, s.Items} {
+			switch v := v.(type) {
+			case *Schema:
+				list = append(list, v)
+			case []*Schema:
+				list = append(list, v...)
+			}
+		}
+		for _, child := range list {
+			visit(child)
+		}
+		state[s] = 2
+	}
+	visit(s)
+	return recursive
+}
+
+// unwrapSchema returns the schema, whose type goType declares
+// for s. It follows $ref and single alternative of s.
+func unwrapSchema(s *Schema) *Schema {
+	for {
+		s = derefSchema(s)
+		if s == nil || s.Bool != nil {
+			return s
+		}
+		alts, _ := alternatives(s)
+		if len(alts) != 1 || isStructLike(s) {
+			return s
+		}
+		s = alts[0]
+	}
+}
+
+// derefSchema follows $ref of s, if s has no other keywords
+// relevant to code generation.
+func derefSchema(s *Schema) *Schema {
+	for s != nil && s.Ref != nil && s.Types == nil && len(s.Properties) == 0 &&
+		len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && s.Enum == nil {
+		s = s.Ref
+	}
+	return s
+}
+
+// alternatives returns the subschemas of oneOf, or else of anyOf, which
+// are not null. It also reports whether null is one of alternatives.
+func alternatives(s *Schema) (alts []*Schema, null bool) {
+	list := s.OneOf
+	if len(list) == 0 {
+		list = s.AnyOf
+	}
+	for _, alt := range list {
+		if d := derefSchema(alt); d != nil && d.Types != nil && *d.Types == Types(nullType) {
+			null = true
+			continue
+		}
+		alts = append(alts, alt)
+	}
+	return alts, null
+}
+
+// schemaTypes returns the types of s, excluding null. If s has
+// no type, it is inferred from other keywords.
+func schemaTypes(s *Schema) Types {
+	var types Types
+	switch {
+	case s.Types != nil:
+		types = *s.Types
+	case s.Enum != nil:
+		types = s.Enum.types
+	case s.Const != nil:
+		types.add(typeOf(*s.Const))
+	case len(s.Properties) > 0 || s.AdditionalProperties != nil:
+		types.add(objectType)
+	case s.Items != nil || s.Items2020 != nil:
+		types.add(arrayType)
+	}
+	return types &^ Types(nullType)
+}
+
+func isStructLike(s *Schema) bool {
+	if len(s.Properties) > 0 {
+		return true
+	}
+	for _, sub := range s.AllOf {
+		if sub = derefSchema(sub); sub != nil && isStructLike(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func needsDecl(s *Schema) bool {
+	alts, _ := alternatives(s)
+	return isStructLike(s) || enumType(s) != "" || len(alts) > 1
+}
+
+// enumType returns the underlying go type of enum in s, if
+// its values are either all strings or all integers.
+func enumType(s *Schema) string {
+	if s.Enum == nil || len(s.Enum.Values) == 0 {
+		return ""
+	}
+	switch s.Enum.types &^ Types(nullType) {
+	case Types(stringType):
+		if s.Enum.types.contains(nullType) {
+			return ""
+		}
+		return "string"
+	case Types(numberType):
+		for _, v := range s.Enum.Values {
+			if n, ok := v.(json.Number); !ok || !isInteger(n) {
+				return ""
+			}
+		}
+		return "int64"
+	}
+	return ""
+}
+
+// sortedProperties returns property names of s, sorted
+// by order and then by name.
+func sortedProperties(s *Schema) []string {
+	var names []string
+	for pname := range s.Properties {
+		names = append(names, pname)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		oa, ob := s.Properties[a].Order, s.Properties[b].Order
+		switch {
+		case oa != nil && ob != nil && *oa != *ob:
+			return *oa - *ob
+		case oa != nil && ob == nil:
+			return -1
+		case oa == nil && ob != nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return names
+}
+
+var goInitialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// goName converts s to exported go identifier.
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var sb strings.Builder
+	for _, w := range words {
+		if goInitialisms[strings.ToLower(w)] {
+			sb.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		sb.WriteString(string(r))
+	}
+	name := sb.String()
+	if name != "" && !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
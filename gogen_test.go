@@ -0,0 +1,142 @@
+package jsonschema_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestGenerateGo(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	sch, err := c.Compile("testdata/gogen/order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := jsonschema.GenerateGo(sch, "dto", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/gogen/order.go.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestGenerateGoDecode compiles the golden source, and checks
+// that json documents round trip through the generated types.
+func TestGenerateGoDecode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go build in short mode")
+	}
+	gocmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	src, err := os.ReadFile("testdata/gogen/order.go.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module dto\n\ngo 1.21\n",
+		"order.go": string(src),
+		"order_test.go": `package dto
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	docs := []string{
+		` + "`" + `{"id":"1","status":"new","items":[],"parent":null,"payment":"cash"}` + "`" + `,
+		` + "`" + `{"id":"2","status":"paid","items":[{"sku":"a"}],"customer":{"version":1,"name":"bob"},
+			"parent":{"id":"1","status":"new","items":[],"parent":null,"payment":"cash"},
+			"payment":{"number":"4111"},"refunds":[{"number":"4111"},5]}` + "`" + `,
+	}
+	for _, doc := range docs {
+		var o Order
+		if err := json.Unmarshal([]byte(doc), &o); err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got, want any
+		json.Unmarshal(b, &got)
+		json.Unmarshal([]byte(doc), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %s, want %s", b, doc)
+		}
+	}
+	var o Order
+	if err := json.Unmarshal([]byte(docs[1]), &o); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.Payment.(Card); !ok {
+		t.Errorf("payment: got %T, want Card", o.Payment)
+	}
+	if _, ok := o.Refunds[1].(OrderRefundsItem2); !ok {
+		t.Errorf("refunds[1]: got %T, want OrderRefundsItem2", o.Refunds[1])
+	}
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(gocmd, "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+}
+
+func TestGenerateGoTypeName(t *testing.T) {
+	sch := compileString(t, `{"type": "array", "items": {"enum": ["a-b", "c"]}}`)
+	got, err := jsonschema.GenerateGo(sch, "main", "Codes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"type Codes []CodesItem", "type CodesItem string", `CodesItemAB CodesItem = "a-b"`} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("%q not found in:\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerateGoRecursive(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"array", `{"properties": {"a": {"type": "array", "items": {"$ref": "#/properties/a"}}}}`, "type SchemaA []SchemaA"},
+		{"map", `{"properties": {"m": {"type": "object", "additionalProperties": {"$ref": "#/properties/m"}}}}`, "type SchemaM map[string]SchemaM"},
+		{"nullable", `{"properties": {"a": {"oneOf": [{"type": "null"}, {"type": "array", "items": {"$ref": "#/properties/a"}}]}}}`, "type SchemaA []SchemaA"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sch := compileString(t, test.schema)
+			got, err := jsonschema.GenerateGo(sch, "main", "Schema")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Contains(got, []byte(test.want)) {
+				t.Errorf("%q not found in:\n%s", test.want, got)
+			}
+		})
+	}
+}
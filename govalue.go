@@ -0,0 +1,409 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	schemaTypeType    = reflect.TypeOf((*ISchemaType)(nil)).Elem()
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// fromGo converts go value v, such as struct, typed slice or typed map,
+// into json value. The conversion follows the rules of [json.Marshal],
+// but without serialization except for [json.Marshaler] implementations.
+//
+// time.Time values are retained as they are, because they are validated
+// against datetime type.
+func fromGo(v any) (any, error) {
+	return (&goConverter{}).value(reflect.ValueOf(v))
+}
+
+// jsonValue returns v converted with fromGo, if v is not json value.
+func jsonValue(v any) (any, error) {
+	if typeOf(v) != invalidType {
+		return v, nil
+	}
+	return fromGo(v)
+}
+
+// cycles are checked only beyond this depth, as
+// encoding/json does, to avoid the cost for common case.
+const startDetectingCyclesAfter = 1000
+
+// goConverter converts go values into json values.
+type goConverter struct {
+	depth int              // nesting of pointers, maps and slices
+	seen  map[any]struct{} // pointers, maps and slices being converted
+}
+
+// enter marks rv, a pointer, map or slice, as being converted.
+// It returns false, if rv is already being converted.
+func (gc *goConverter) enter(rv reflect.Value) bool {
+	gc.depth++
+	if gc.depth <= startDetectingCyclesAfter {
+		return true
+	}
+	if gc.seen == nil {
+		gc.seen = map[any]struct{}{}
+	}
+	key := cycleKey(rv)
+	if _, ok := gc.seen[key]; ok {
+		return false
+	}
+	gc.seen[key] = struct{}{}
+	return true
+}
+
+// leave undoes enter(rv).
+func (gc *goConverter) leave(rv reflect.Value) {
+	if gc.depth > startDetectingCyclesAfter {
+		delete(gc.seen, cycleKey(rv))
+	}
+	gc.depth--
+}
+
+// cycleKey identifies the pointer, map or slice rv.
+func cycleKey(rv reflect.Value) any {
+	if rv.Kind() == reflect.Slice {
+		// slices of different length may share pointer
+		return struct {
+			ptr unsafe.Pointer
+			len int
+		}{rv.UnsafePointer(), rv.Len()}
+	}
+	return rv.UnsafePointer()
+}
+
+func (gc *goConverter) value(rv reflect.Value) (any, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return nil, nil
+	}
+
+	t := rv.Type()
+	if t == timeType || t.Implements(schemaTypeType) {
+		return rv.Interface(), nil
+	}
+	if t.Kind() == reflect.Pointer && t.Elem() == timeType {
+		return rv.Elem().Interface(), nil
+	}
+	if t == jsonNumberType {
+		return json.Number(rv.String()), nil
+	}
+	if t.Implements(jsonMarshalerType) {
+		return marshalJSON(rv.Interface().(json.Marshaler))
+	}
+	if rv.Kind() != reflect.Pointer && rv.CanAddr() && reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return marshalJSON(rv.Addr().Interface().(json.Marshaler))
+	}
+	if t.Implements(textMarshalerType) {
+		b, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, &UnsupportedValueError{rv.Type().String(), strconv.FormatFloat(f, 'g', -1, 64)}
+		}
+		if rv.Kind() == reflect.Float32 {
+			return float32(f), nil
+		}
+		return f, nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Interface:
+		return gc.value(rv.Elem())
+	case reflect.Pointer:
+		if !gc.enter(rv) {
+			return nil, &UnsupportedValueError{t.String(), "cycle"}
+		}
+		defer gc.leave(rv)
+		return gc.value(rv.Elem())
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(jsonMarshalerType) && !reflect.PointerTo(t.Elem()).Implements(textMarshalerType) {
+			return base64.StdEncoding.EncodeToString(rv.Bytes()), nil
+		}
+		if !gc.enter(rv) {
+			return nil, &UnsupportedValueError{t.String(), "cycle"}
+		}
+		defer gc.leave(rv)
+		return gc.array(rv)
+	case reflect.Array:
+		return gc.array(rv)
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if !gc.enter(rv) {
+			return nil, &UnsupportedValueError{t.String(), "cycle"}
+		}
+		defer gc.leave(rv)
+		obj := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			pname, err := goMapKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			pvalue, err := gc.value(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			obj[pname] = pvalue
+		}
+		return obj, nil
+	case reflect.Struct:
+		return gc.structValue(rv)
+	}
+	return nil, &UnsupportedValueError{t.String(), ""}
+}
+
+func marshalJSON(m json.Marshaler) (any, error) {
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalJSON(bytes.NewReader(b))
+}
+
+func (gc *goConverter) array(rv reflect.Value) (any, error) {
+	arr := make([]any, rv.Len())
+	for i := range arr {
+		item, err := gc.value(rv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = item
+	}
+	return arr, nil
+}
+
+func goMapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", &UnsupportedValueError{k.Type().String(), "map key"}
+}
+
+func (gc *goConverter) structValue(rv reflect.Value) (any, error) {
+	obj := map[string]any{}
+fields:
+	for _, f := range structFields(rv.Type()) {
+		fv := rv
+		for _, i := range f.index {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue fields // embedded nil pointer
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(i)
+		}
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		pvalue, err := gc.value(fv)
+		if err != nil {
+			return nil, err
+		}
+		if f.quoted {
+			switch pvalue.(type) {
+			case bool, int64, uint64, float32, float64:
+				pvalue = fmt.Sprint(pvalue)
+			case string:
+				b, _ := json.Marshal(pvalue)
+				pvalue = string(b)
+			}
+		}
+		obj[f.name] = pvalue
+	}
+	return obj, nil
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// --
+
+type goField struct {
+	name      string
+	index     []int
+	tagged    bool // name is from json tag
+	omitEmpty bool
+	quoted    bool // ",string" option
+}
+
+var fieldCache sync.Map // map[reflect.Type][]goField
+
+// structFields returns the fields of struct type t, which are encoded
+// by encoding/json, including the promoted fields of embedded structs.
+func structFields(t reflect.Type) []goField {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]goField)
+	}
+	var fields []goField
+	collectFields(t, nil, map[reflect.Type]struct{}{}, &fields)
+
+	// resolve name conflicts: shallowest wins, tagged wins
+	// among same depth. others are dropped.
+	byName := map[string][]goField{}
+	var names []string
+	for _, f := range fields {
+		if _, ok := byName[f.name]; !ok {
+			names = append(names, f.name)
+		}
+		byName[f.name] = append(byName[f.name], f)
+	}
+	var result []goField
+	for _, name := range names {
+		if f, ok := dominantField(byName[name]); ok {
+			result = append(result, f)
+		}
+	}
+	fieldCache.Store(t, result)
+	return result
+}
+
+func collectFields(t reflect.Type, index []int, visited map[reflect.Type]struct{}, fields *[]goField) {
+	if _, ok := visited[t]; ok {
+		return
+	}
+	visited[t] = struct{}{}
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous {
+			if !sf.IsExported() && ft.Kind() != reflect.Struct {
+				continue
+			}
+		} else if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		findex := append(append([]int(nil), index...), i)
+		if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+			collectFields(ft, findex, visited, fields)
+			continue
+		}
+		f := goField{name: name, index: findex, tagged: name != ""}
+		if name == "" {
+			f.name = sf.Name
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "string":
+				f.quoted = true
+			}
+		}
+		*fields = append(*fields, f)
+	}
+}
+
+func dominantField(fields []goField) (goField, bool) {
+	depth := len(fields[0].index)
+	var dominant []goField
+	for _, f := range fields {
+		switch {
+		case len(f.index) < depth:
+			depth = len(f.index)
+			dominant = []goField{f}
+		case len(f.index) == depth:
+			dominant = append(dominant, f)
+		}
+	}
+	if len(dominant) > 1 {
+		var tagged []goField
+		for _, f := range dominant {
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+		dominant = tagged
+	}
+	if len(dominant) != 1 {
+		return goField{}, false
+	}
+	return dominant[0], true
+}
+
+// --
+
+// UnsupportedValueError is returned when go value
+// cannot be converted into json value.
+type UnsupportedValueError struct {
+	Type  string
+	Value string
+}
+
+func (e *UnsupportedValueError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("unsupported type %s", e.Type)
+	}
+	return fmt.Sprintf("unsupported value %s of type %s", e.Value, e.Type)
+}
@@ -0,0 +1,143 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+type color int
+
+func (c color) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{"red", "green"}[c])
+}
+
+type audit struct {
+	CreatedBy string `json:"createdBy"`
+	Version   int    `json:"version,omitempty"`
+}
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,string"`
+}
+
+type person struct {
+	*audit
+	Name     string            `json:"name"`
+	Nick     string            `json:"nick,omitempty"`
+	Secret   string            `json:"-"`
+	Age      uint8             `json:"age"`
+	Tags     []string          `json:"tags"`
+	Scores   map[string]int    `json:"scores"`
+	Address  *address          `json:"address"`
+	Color    color             `json:"color"`
+	Birthday time.Time         `json:"birthday"`
+	Extra    map[string]string `json:"extra,omitempty"`
+	internal int
+}
+
+func TestValidateGoValues(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "object",
+		"properties": {
+			"createdBy": { "type": "string", "minLength": 1 },
+			"name": { "type": "string", "minLength": 2 },
+			"age": { "type": "integer", "maximum": 150 },
+			"tags": { "type": "array", "items": { "type": "string" }, "uniqueItems": true },
+			"scores": { "type": "object", "additionalProperties": { "type": "integer" } },
+			"address": {
+				"type": ["object", "null"],
+				"properties": { "zip": { "type": "string", "pattern": "^\"[0-9]+\"$" } }
+			},
+			"color": { "enum": ["red", "green"] },
+			"birthday": { "type": "datetime" }
+		},
+		"required": ["name", "age", "tags", "color"],
+		"additionalProperties": false,
+		"patternProperties": { "^(nick|createdBy|version|extra)$": true }
+	}`)
+
+	p := person{
+		audit:    &audit{CreatedBy: "admin"},
+		Name:     "alice",
+		Secret:   "xyz",
+		Age:      30,
+		Tags:     []string{"a", "b"},
+		Scores:   map[string]int{"math": 90},
+		Address:  &address{City: "x", Zip: "123"},
+		Color:    1,
+		Birthday: time.Now(),
+	}
+	for _, v := range []any{p, &p} {
+		if err := sch.Validate(v); err != nil {
+			t.Fatalf("%T: %v", v, err)
+		}
+	}
+
+	p.Age = 200
+	p.Tags = []string{"a", "a"}
+	p.audit.CreatedBy = ""
+	err := sch.Validate(p)
+	if err == nil {
+		t.Fatal("validation must fail")
+	}
+	for _, want := range []string{"/age", "/tags", "/createdBy"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error must mention %s:\n%v", want, err)
+		}
+	}
+
+	// typed values inside generic values
+	sch = compileString(t, `{"type": "array", "items": {"type": "array"}, "uniqueItems": true}`)
+	if err := sch.Validate([]any{[]int{1, 2}, []int{1, 2}}); err == nil {
+		t.Error("uniqueItems must fail")
+	}
+	if err := sch.Validate([]any{[]int{1, 2}, []string{"1"}}); err != nil {
+		t.Error(err)
+	}
+}
+
+type cyclicNode struct {
+	Next *cyclicNode `json:"next"`
+}
+
+type cyclicMap map[string]any
+
+type cyclicSlice []any
+
+func TestValidateGoValuesCyclic(t *testing.T) {
+	node := &cyclicNode{}
+	node.Next = node
+	m := cyclicMap{}
+	m["self"] = m
+	s := cyclicSlice{nil}
+	s[0] = s
+
+	sch := compileString(t, `{"not": false}`)
+	for _, v := range []any{node, m, s} {
+		err := sch.Validate(v)
+		var uerr *jsonschema.UnsupportedValueError
+		if !errors.As(err, &uerr) || uerr.Value != "cycle" {
+			t.Errorf("%T: got %v, want UnsupportedValueError for cycle", v, err)
+		}
+	}
+}
+
+func TestValidateGoValuesUnsupported(t *testing.T) {
+	sch := compileString(t, `{"not": false}`)
+	err := sch.Validate(struct{ C chan int }{})
+	var uerr *jsonschema.UnsupportedValueError
+	if !errors.As(err, &uerr) || uerr.Type != "chan int" {
+		t.Errorf("got %v, want UnsupportedValueError for chan int", err)
+	}
+	var k *kind.InvalidJsonValue
+	if !errors.As(err, &k) {
+		t.Errorf("got %v, want kind.InvalidJsonValue", err)
+	}
+}
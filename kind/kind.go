@@ -5,13 +5,21 @@ import (
 	"math/big"
 	"strings"
 
+	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
 
+// Kinds implement error, so that they can be matched using
+// errors.Is and errors.As against ValidationError. Error returns
+// the message in english.
+
+var defaultPrinter = message.NewPrinter(language.English)
+
 // --
 
 type InvalidJsonValue struct {
 	Value any
+	Err   error // why go value could not be converted, if known
 }
 
 func (*InvalidJsonValue) KeywordPath() []string {
@@ -19,9 +27,20 @@ func (*InvalidJsonValue) KeywordPath() []string {
 }
 
 func (k *InvalidJsonValue) LocalizedString(p *message.Printer) string {
+	if k.Err != nil {
+		return p.Sprintf("invalid jsonType %T: %v", k.Value, localizedError(k.Err, p))
+	}
 	return p.Sprintf("invalid jsonType %T", k.Value)
 }
 
+func (k *InvalidJsonValue) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+func (k *InvalidJsonValue) Unwrap() error {
+	return k.Err
+}
+
 // --
 
 type Schema struct {
@@ -36,6 +55,10 @@ func (k *Schema) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("jsonschema validation failed with %s", quote(k.Location))
 }
 
+func (k *Schema) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Group struct{}
@@ -48,6 +71,10 @@ func (*Group) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("validation failed")
 }
 
+func (k *Group) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Not struct{}
@@ -60,6 +87,10 @@ func (*Not) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("not failed")
 }
 
+func (k *Not) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type AllOf struct{}
@@ -72,6 +103,10 @@ func (*AllOf) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("allOf failed")
 }
 
+func (k *AllOf) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type AnyOf struct{}
@@ -84,6 +119,10 @@ func (*AnyOf) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("anyOf failed")
 }
 
+func (k *AnyOf) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type OneOf struct {
@@ -103,6 +142,10 @@ func (k *OneOf) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("oneOf failed, subschemas %d, %d matched", k.Subschemas[0], k.Subschemas[1])
 }
 
+func (k *OneOf) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 //--
 
 type FalseSchema struct{}
@@ -115,6 +158,10 @@ func (*FalseSchema) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("false schema")
 }
 
+func (k *FalseSchema) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type RefCycle struct {
@@ -131,6 +178,10 @@ func (k *RefCycle) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("both %s and %s resolve to %q causing reference cycle", k.KeywordLocation1, k.KeywordLocation2, k.URL)
 }
 
+func (k *RefCycle) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Type struct {
@@ -147,6 +198,46 @@ func (k *Type) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("got %s, want %s", k.Got, want)
 }
 
+func (k *Type) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type Coercion struct {
+	Got  any
+	Want []string
+}
+
+func (*Coercion) KeywordPath() []string {
+	return []string{"type"}
+}
+
+func (k *Coercion) LocalizedString(p *message.Printer) string {
+	want := strings.Join(k.Want, " or ")
+	return p.Sprintf("cannot coerce %s to %s", display(k.Got), want)
+}
+
+func (k *Coercion) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type NotNull struct{}
+
+func (*NotNull) KeywordPath() []string {
+	return []string{"notNull"}
+}
+
+func (*NotNull) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("null is not allowed")
+}
+
+func (k *NotNull) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Enum struct {
@@ -182,6 +273,10 @@ loop:
 	return p.Sprintf("enum failed")
 }
 
+func (k *Enum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Const struct {
@@ -202,6 +297,10 @@ func (k *Const) LocalizedString(p *message.Printer) string {
 	}
 }
 
+func (k *Const) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Format struct {
@@ -218,6 +317,10 @@ func (k *Format) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("%s is not valid %s: %v", display(k.Got), k.Want, localizedError(k.Err, p))
 }
 
+func (k *Format) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Reference struct {
@@ -233,6 +336,10 @@ func (*Reference) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("validation failed")
 }
 
+func (k *Reference) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MinProperties struct {
@@ -247,6 +354,10 @@ func (k *MinProperties) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("minProperties: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MinProperties) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MaxProperties struct {
@@ -261,6 +372,10 @@ func (k *MaxProperties) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("maxProperties: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MaxProperties) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MinItems struct {
@@ -275,6 +390,10 @@ func (k *MinItems) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("minItems: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MinItems) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MaxItems struct {
@@ -289,6 +408,10 @@ func (k *MaxItems) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("maxItems: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MaxItems) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type AdditionalItems struct {
@@ -303,6 +426,10 @@ func (k *AdditionalItems) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("last %d additionalItem(s) not allowed", k.Count)
 }
 
+func (k *AdditionalItems) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Required struct {
@@ -320,6 +447,10 @@ func (k *Required) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("missing properties %s", joinQuoted(k.Missing, ", "))
 }
 
+func (k *Required) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Dependency struct {
@@ -335,6 +466,10 @@ func (k *Dependency) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("properties %s required, if %s exists", joinQuoted(k.Missing, ", "), quote(k.Prop))
 }
 
+func (k *Dependency) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type DependentRequired struct {
@@ -350,6 +485,10 @@ func (k *DependentRequired) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("properties %s required, if %s exists", joinQuoted(k.Missing, ", "), quote(k.Prop))
 }
 
+func (k *DependentRequired) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type AdditionalProperties struct {
@@ -364,6 +503,10 @@ func (k *AdditionalProperties) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("additional properties %s not allowed", joinQuoted(k.Properties, ", "))
 }
 
+func (k *AdditionalProperties) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type PropertyNames struct {
@@ -378,6 +521,10 @@ func (k *PropertyNames) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("invalid propertyName %s", quote(k.Property))
 }
 
+func (k *PropertyNames) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type UniqueItems struct {
@@ -392,6 +539,10 @@ func (k *UniqueItems) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("items at %d and %d are equal", k.Duplicates[0], k.Duplicates[1])
 }
 
+func (k *UniqueItems) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Contains struct{}
@@ -404,6 +555,10 @@ func (*Contains) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("no items match contains schema")
 }
 
+func (k *Contains) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MinContains struct {
@@ -424,6 +579,10 @@ func (k *MinContains) LocalizedString(p *message.Printer) string {
 	}
 }
 
+func (k *MinContains) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MaxContains struct {
@@ -440,6 +599,10 @@ func (k *MaxContains) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("max %d items required to match contains schema, but matched %d items at %v", k.Want, len(k.Got), got[1:len(got)-1])
 }
 
+func (k *MaxContains) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MinLength struct {
@@ -454,6 +617,10 @@ func (k *MinLength) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("minLength: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MinLength) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MaxLength struct {
@@ -468,6 +635,10 @@ func (k *MaxLength) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("maxLength: got %d, want %d", k.Got, k.Want)
 }
 
+func (k *MaxLength) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Pattern struct {
@@ -483,6 +654,10 @@ func (k *Pattern) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("%s does not match pattern %s", quote(k.Got), quote(k.Want))
 }
 
+func (k *Pattern) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type ContentEncoding struct {
@@ -498,6 +673,10 @@ func (k *ContentEncoding) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("value is not %s encoded: %v", quote(k.Want), localizedError(k.Err, p))
 }
 
+func (k *ContentEncoding) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type ContentMediaType struct {
@@ -511,7 +690,11 @@ func (*ContentMediaType) KeywordPath() []string {
 }
 
 func (k *ContentMediaType) LocalizedString(p *message.Printer) string {
-	return p.Sprintf("value if not of mediatype %s: %v", quote(k.Want), k.Err)
+	return p.Sprintf("value if not of mediatype %s: %v", quote(k.Want), localizedError(k.Err, p))
+}
+
+func (k *ContentMediaType) Error() string {
+	return k.LocalizedString(defaultPrinter)
 }
 
 // --
@@ -526,6 +709,10 @@ func (*ContentSchema) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("contentSchema failed")
 }
 
+func (k *ContentSchema) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Minimum struct {
@@ -543,6 +730,10 @@ func (k *Minimum) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("minimum: got %v, want %v", got, want)
 }
 
+func (k *Minimum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type Maximum struct {
@@ -560,6 +751,10 @@ func (k *Maximum) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("maximum: got %v, want %v", got, want)
 }
 
+func (k *Maximum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type ExclusiveMinimum struct {
@@ -577,6 +772,10 @@ func (k *ExclusiveMinimum) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("exclusiveMinimum: got %v, want %v", got, want)
 }
 
+func (k *ExclusiveMinimum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type ExclusiveMaximum struct {
@@ -594,6 +793,10 @@ func (k *ExclusiveMaximum) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("exclusiveMaximum: got %v, want %v", got, want)
 }
 
+func (k *ExclusiveMaximum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 type MultipleOf struct {
@@ -611,6 +814,86 @@ func (k *MultipleOf) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("multipleOf: got %v, want %v", got, want)
 }
 
+func (k *MultipleOf) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type DateMinimum struct {
+	Got  string
+	Want string
+}
+
+func (*DateMinimum) KeywordPath() []string {
+	return []string{"dateMinimum"}
+}
+
+func (k *DateMinimum) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("dateMinimum: got %s, want %s", k.Got, k.Want)
+}
+
+func (k *DateMinimum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type DateMaximum struct {
+	Got  string
+	Want string
+}
+
+func (*DateMaximum) KeywordPath() []string {
+	return []string{"dateMaximum"}
+}
+
+func (k *DateMaximum) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("dateMaximum: got %s, want %s", k.Got, k.Want)
+}
+
+func (k *DateMaximum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type DateExclusiveMinimum struct {
+	Got  string
+	Want string
+}
+
+func (*DateExclusiveMinimum) KeywordPath() []string {
+	return []string{"dateExclusiveMinimum"}
+}
+
+func (k *DateExclusiveMinimum) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("dateExclusiveMinimum: got %s, want %s", k.Got, k.Want)
+}
+
+func (k *DateExclusiveMinimum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
+// --
+
+type DateExclusiveMaximum struct {
+	Got  string
+	Want string
+}
+
+func (*DateExclusiveMaximum) KeywordPath() []string {
+	return []string{"dateExclusiveMaximum"}
+}
+
+func (k *DateExclusiveMaximum) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("dateExclusiveMaximum: got %s, want %s", k.Got, k.Want)
+}
+
+func (k *DateExclusiveMaximum) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
+
 // --
 
 func quote(s string) string {
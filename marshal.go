@@ -0,0 +1,439 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Document returns json schema document equivalent to s. The document
+// has "$schema" of the draft s is compiled with.
+//
+// References are emitted as "$ref" strings. Schemas referred from s,
+// which are in the same document as s, but are not subschemas of s
+// by keyword, such as those in $defs, are emitted at their original
+// locations. References to other schemas use their absolute location.
+//
+// Keywords of custom vocabularies are not emitted.
+func (s *Schema) Document() any {
+	m := &marshaller{root: s, emitted: map[*Schema]bool{}}
+	doc := m.value(s)
+	for len(m.pending) > 0 {
+		sch := m.pending[0]
+		m.pending = m.pending[1:]
+		if m.emitted[sch] {
+			continue
+		}
+		m.place(doc, strings.TrimPrefix(string(sch.up.ptr), string(s.up.ptr)), m.value(sch))
+	}
+	if obj, ok := doc.(map[string]any); ok {
+		if d := draftFromVersion(s.DraftVersion); d != nil {
+			obj["$schema"] = d.url
+		}
+	}
+	return doc
+}
+
+// MarshalJSON returns json encoding of [Schema.Document].
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Document())
+}
+
+// keywordsMarshaler is implemented by extensions of builtin
+// vocabularies, to include their keywords in Schema.Document.
+type keywordsMarshaler interface {
+	marshalKeywords() map[string]any
+}
+
+type marshaller struct {
+	root    *Schema
+	emitted map[*Schema]bool
+	pending []*Schema // referred schemas to be placed in document
+
+	// dereference mode --
+	deref  *DereferenceOptions
+	draft  int             // version of draft, whose keywords are emitted
+	nested map[*Schema]int // number of times schema is being emitted
+	scope  []*Schema       // dynamic scope, outermost first
+	err    error
+}
+
+// inDocument tells whether sch can be placed in the document of root.
+func (m *marshaller) inDocument(sch *Schema) bool {
+	if sch.up.url != m.root.up.url {
+		return false
+	}
+	ptr, rootPtr := string(sch.up.ptr), string(m.root.up.ptr)
+	return ptr == rootPtr || strings.HasPrefix(ptr, rootPtr+"/")
+}
+
+// ref returns the reference to sch, from schema from.
+func (m *marshaller) ref(from, sch *Schema) string {
+	if !m.inDocument(sch) {
+		return sch.Location
+	}
+	m.pending = append(m.pending, sch)
+
+	// json-pointer is relative to base resource of from
+	base := string(from.resource.up.ptr)
+	if !m.inDocument(from.resource) {
+		base = string(m.root.up.ptr)
+	}
+	ptr := string(sch.up.ptr)
+	if ptr != base && !strings.HasPrefix(ptr, base+"/") {
+		return sch.Location
+	}
+	return "#" + encode(ptr[len(base):])
+}
+
+// place sets v at json-pointer ptr in doc.
+func (m *marshaller) place(doc any, ptr string, v any) {
+	toks := strings.Split(ptr, "/")[1:]
+	for i, tok := range toks {
+		tok, _ = unescape(tok)
+		last := i == len(toks)-1
+		switch d := doc.(type) {
+		case map[string]any:
+			if last {
+				d[tok] = v
+				return
+			}
+			if _, ok := d[tok]; !ok {
+				d[tok] = map[string]any{}
+			}
+			doc = d[tok]
+		case []any:
+			index, err := strconv.Atoi(tok)
+			if err != nil || index < 0 || index >= len(d) {
+				return
+			}
+			if last {
+				d[index] = v
+				return
+			}
+			doc = d[index]
+		default:
+			return
+		}
+	}
+}
+
+// value returns json value of sch.
+func (m *marshaller) value(sch *Schema) any {
+	m.emitted[sch] = true
+	draft := sch.DraftVersion
+	if m.deref != nil {
+		draft = m.draft
+	}
+	if sch.Bool != nil {
+		return boolValue(*sch.Bool, draft)
+	}
+	if m.deref != nil {
+		m.nested[sch]++
+		m.scope = append(m.scope, sch)
+		defer func() {
+			m.nested[sch]--
+			m.scope = m.scope[:len(m.scope)-1]
+		}()
+	}
+
+	obj := map[string]any{}
+	schemas := func(list []*Schema) []any {
+		var arr []any
+		for _, sch := range list {
+			arr = append(arr, m.value(sch))
+		}
+		return arr
+	}
+	schemaMap := func(pname string, sm map[string]*Schema) {
+		if sm == nil {
+			return
+		}
+		vm := map[string]any{}
+		for k, sch := range sm {
+			vm[k] = m.value(sch)
+		}
+		obj[pname] = vm
+	}
+	prop := func(pname string, sch *Schema) {
+		if sch != nil {
+			obj[pname] = m.value(sch)
+		}
+	}
+	num := func(pname string, n *big.Rat) {
+		if n != nil {
+			obj[pname] = ratNumber(n)
+		}
+	}
+	integer := func(pname string, n *int) {
+		if n != nil {
+			obj[pname] = json.Number(strconv.Itoa(*n))
+		}
+	}
+	str := func(pname string, s string) {
+		if s != "" {
+			obj[pname] = s
+		}
+	}
+	boolean := func(pname string, b bool) {
+		if b {
+			obj[pname] = true
+		}
+	}
+	dateBound := func(pname string, b *DateBound) {
+		if b != nil {
+			obj[pname] = b.Value
+		}
+	}
+
+	// core --
+	if m.deref == nil {
+		id := sch.ID
+		idKeyword := "$id"
+		if sch.DraftVersion < 6 {
+			idKeyword = "id"
+		}
+		if sch.DraftVersion < 2019 {
+			if sch.Anchor != "" {
+				id += "#" + sch.Anchor
+			}
+		} else {
+			str("$anchor", sch.Anchor)
+		}
+		if sch.Ref != nil {
+			obj["$ref"] = m.ref(sch, sch.Ref)
+			if sch.DraftVersion < 2019 {
+				// all other properties in "$ref" object are ignored
+				return obj
+			}
+		}
+		str(idKeyword, id)
+		if sch.resource == sch && sch.DraftVersion != m.root.DraftVersion {
+			// mixed dialect
+			if d := draftFromVersion(sch.DraftVersion); d != nil {
+				obj["$schema"] = d.url
+			}
+		}
+		if sch.RecursiveRef != nil {
+			obj["$recursiveRef"] = "#"
+		}
+		boolean("$recursiveAnchor", sch.RecursiveAnchor)
+		if sch.DynamicRef != nil {
+			ref := m.ref(sch, sch.DynamicRef.Ref)
+			if sch.DynamicRef.Anchor != "" {
+				ref, _, _ = strings.Cut(ref, "#")
+				ref += "#" + sch.DynamicRef.Anchor
+			}
+			obj["$dynamicRef"] = ref
+		}
+		str("$dynamicAnchor", sch.DynamicAnchor)
+	}
+
+	// type agnostic --
+	if sch.Types != nil {
+		if types := sch.Types.ToStrings(); len(types) == 1 {
+			obj["type"] = types[0]
+		} else {
+			obj["type"] = stringsValue(types)
+		}
+	}
+	if sch.Enum != nil {
+		obj["enum"] = sch.Enum.Values
+	}
+	if sch.Const != nil {
+		obj["const"] = *sch.Const
+	}
+	prop("not", sch.Not)
+	if sch.AllOf != nil {
+		obj["allOf"] = schemas(sch.AllOf)
+	}
+	if sch.AnyOf != nil {
+		obj["anyOf"] = schemas(sch.AnyOf)
+	}
+	if sch.OneOf != nil {
+		obj["oneOf"] = schemas(sch.OneOf)
+	}
+	prop("if", sch.If)
+	prop("then", sch.Then)
+	prop("else", sch.Else)
+	str("format", sch.formatName)
+
+	// object --
+	integer("maxProperties", sch.MaxProperties)
+	integer("minProperties", sch.MinProperties)
+	if sch.Required != nil {
+		obj["required"] = stringsValue(sch.Required)
+	}
+	prop("propertyNames", sch.PropertyNames)
+	schemaMap("properties", sch.Properties)
+	if sch.PatternProperties != nil {
+		pm := map[string]*Schema{}
+		for re, sch := range sch.PatternProperties {
+			pm[re.String()] = sch
+		}
+		schemaMap("patternProperties", pm)
+	}
+	m.additional(obj, "additionalProperties", sch.AdditionalProperties)
+	if sch.Dependencies != nil {
+		deps := map[string]any{}
+		for pname, dep := range sch.Dependencies {
+			switch dep := dep.(type) {
+			case []string:
+				deps[pname] = stringsValue(dep)
+			case *Schema:
+				deps[pname] = m.value(dep)
+			}
+		}
+		obj["dependencies"] = deps
+	}
+	if sch.DependentRequired != nil {
+		dr := map[string]any{}
+		for pname, required := range sch.DependentRequired {
+			dr[pname] = stringsValue(required)
+		}
+		obj["dependentRequired"] = dr
+	}
+	schemaMap("dependentSchemas", sch.DependentSchemas)
+	prop("unevaluatedProperties", sch.UnevaluatedProperties)
+
+	// array --
+	integer("minItems", sch.MinItems)
+	integer("maxItems", sch.MaxItems)
+	boolean("uniqueItems", sch.UniqueItems)
+	prop("contains", sch.Contains)
+	integer("minContains", sch.MinContains)
+	integer("maxContains", sch.MaxContains)
+	switch items := sch.Items.(type) {
+	case *Schema:
+		obj["items"] = m.value(items)
+	case []*Schema:
+		obj["items"] = schemas(items)
+	}
+	m.additional(obj, "additionalItems", sch.AdditionalItems)
+	if sch.PrefixItems != nil {
+		obj["prefixItems"] = schemas(sch.PrefixItems)
+	}
+	prop("items", sch.Items2020)
+	prop("unevaluatedItems", sch.UnevaluatedItems)
+
+	// string --
+	integer("minLength", sch.MinLength)
+	integer("maxLength", sch.MaxLength)
+	if sch.Pattern != nil {
+		obj["pattern"] = sch.Pattern.String()
+	}
+	str("contentEncoding", sch.contentEncodingName)
+	str("contentMediaType", sch.contentMediaTypeName)
+	prop("contentSchema", sch.ContentSchema)
+
+	// number --
+	num("maximum", sch.Maximum)
+	num("minimum", sch.Minimum)
+	if draft < 6 {
+		if sch.ExclusiveMaximum != nil {
+			num("maximum", sch.ExclusiveMaximum)
+			obj["exclusiveMaximum"] = true
+		}
+		if sch.ExclusiveMinimum != nil {
+			num("minimum", sch.ExclusiveMinimum)
+			obj["exclusiveMinimum"] = true
+		}
+	} else {
+		num("exclusiveMaximum", sch.ExclusiveMaximum)
+		num("exclusiveMinimum", sch.ExclusiveMinimum)
+	}
+	num("multipleOf", sch.MultipleOf)
+
+	// date, datetime --
+	dateBound("dateMinimum", sch.DateMinimum)
+	dateBound("dateMaximum", sch.DateMaximum)
+	dateBound("dateExclusiveMinimum", sch.DateExclusiveMinimum)
+	dateBound("dateExclusiveMaximum", sch.DateExclusiveMaximum)
+
+	// annotations --
+	str("title", sch.Title)
+	str("description", sch.Description)
+	if sch.Default != nil {
+		obj["default"] = *sch.Default
+	}
+	str("$comment", sch.Comment)
+	boolean("readOnly", sch.ReadOnly)
+	boolean("writeOnly", sch.WriteOnly)
+	if sch.Examples != nil {
+		obj["examples"] = sch.Examples
+	}
+	boolean("deprecated", sch.Deprecated)
+
+	// liuxd extend field
+	integer("order", sch.Order)
+	if sch.Name != sch.getName() {
+		str("name", sch.Name)
+	}
+
+	for _, ext := range sch.Extensions {
+		if km, ok := ext.(keywordsMarshaler); ok {
+			for k, v := range km.marshalKeywords() {
+				obj[k] = v
+			}
+		}
+	}
+	if m.deref != nil {
+		translateKeywords(obj, draft)
+		return m.inlineRefs(sch, obj)
+	}
+	return obj
+}
+
+// additional sets v, which is nil or bool or *Schema, as obj[pname].
+func (m *marshaller) additional(obj map[string]any, pname string, v any) {
+	switch v := v.(type) {
+	case bool:
+		obj[pname] = v
+	case *Schema:
+		obj[pname] = m.value(v)
+	}
+}
+
+// boolValue returns json value of boolean schema b.
+func boolValue(b bool, draftVersion int) any {
+	if draftVersion < 6 {
+		// boolean schemas are not supported
+		if b {
+			return map[string]any{}
+		}
+		return map[string]any{"not": map[string]any{}}
+	}
+	return b
+}
+
+// ratNumber returns n as json number. If n has no finite decimal
+// representation, it is approximated to float64.
+func ratNumber(n *big.Rat) json.Number {
+	if n.IsInt() {
+		return json.Number(n.Num().String())
+	}
+	// decimal digits needed: max power of 2 and 5 in denominator
+	d := new(big.Int).Set(n.Denom())
+	var twos, fives int
+	for two := big.NewInt(2); new(big.Int).Mod(d, two).Sign() == 0; twos++ {
+		d.Quo(d, two)
+	}
+	for five := big.NewInt(5); new(big.Int).Mod(d, five).Sign() == 0; fives++ {
+		d.Quo(d, five)
+	}
+	if d.IsInt64() && d.Int64() == 1 {
+		return json.Number(n.FloatString(max(twos, fives)))
+	}
+	f, _ := n.Float64()
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// stringsValue returns arr as json value.
+func stringsValue(arr []string) []any {
+	v := make([]any, len(arr))
+	for i, s := range arr {
+		v[i] = s
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestSchemaDocument(t *testing.T) {
+	f, err := os.Open("testdata/marshal.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var tests []struct {
+		Description string
+		Draft       int
+		Schema      any
+		Marshal     any
+	}
+	dec := json.NewDecoder(f)
+	dec.UseNumber()
+	if err := dec.Decode(&tests); err != nil {
+		t.Fatal(err)
+	}
+	drafts := map[int]*jsonschema.Draft{4: jsonschema.Draft4, 6: jsonschema.Draft6, 2020: jsonschema.Draft2020}
+	for _, test := range tests {
+		t.Run(test.Description, func(t *testing.T) {
+			c := jsonschema.NewCompiler()
+			if d, ok := drafts[test.Draft]; ok {
+				c.DefaultDraft(d)
+			}
+			if err := c.AddResource("schema.json", test.Schema); err != nil {
+				t.Fatal(err)
+			}
+			sch, err := c.Compile("schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := test.Schema
+			if test.Marshal != nil {
+				want = test.Marshal
+			}
+			got := sch.Document()
+			if obj, ok := got.(map[string]any); ok {
+				delete(obj, "$schema")
+			}
+			if !jsonEqual(t, got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	sch := compileString(t, `{
+		"$defs": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"value": { "type": "number", "exclusiveMinimum": 0.25, "multipleOf": 0.05 },
+					"next": { "$ref": "#/$defs/node" }
+				},
+				"patternProperties": { "^x-": { "type": "string", "format": "email" } },
+				"additionalProperties": false
+			}
+		},
+		"type": "array",
+		"items": { "$ref": "#/$defs/node" },
+		"prefixItems": [{ "const": null }],
+		"contentEncoding": "base64"
+	}`)
+	b, err := json.Marshal(sch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"value": { "type": "number", "exclusiveMinimum": 0.25, "multipleOf": 0.05 },
+					"next": { "$ref": "#/$defs/node" }
+				},
+				"patternProperties": { "^x-": { "type": "string", "format": "email" } },
+				"additionalProperties": false
+			}
+		},
+		"type": "array",
+		"items": { "$ref": "#/$defs/node" },
+		"prefixItems": [{ "const": null }],
+		"contentEncoding": "base64"
+	}`
+	if !jsonEqual(t, unmarshalString(t, string(b)), unmarshalString(t, want)) {
+		t.Fatalf("got %s", b)
+	}
+
+	// compiled document must validate same as original
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("copy.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	copySch, err := c.Compile("copy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, inst := range []string{
+		`[null, {"value": 0.5, "next": {"value": 1}}]`,
+		`[null, {"value": 0.25}]`,
+		`[null, {"value": 0.5, "next": {"value": 1, "other": 1}}]`,
+		`[null, {"x-mail": "a@b.c"}]`,
+		`[1]`,
+	} {
+		v := unmarshalString(t, inst)
+		if got, want := copySch.Validate(v) == nil, sch.Validate(v) == nil; got != want {
+			t.Errorf("%s: got %v, want %v", inst, got, want)
+		}
+	}
+}
+
+func TestSchemaDocumentDraft4(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft4)
+	doc := unmarshalString(t, `{
+		"definitions": { "pos": { "type": "integer", "minimum": 0, "exclusiveMinimum": true } },
+		"properties": {
+			"a": { "$ref": "#/definitions/pos" },
+			"b": { "$ref": "other.json#/definitions/x" }
+		}
+	}`)
+	if err := c.AddResource("http://example.com/schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/other.json", unmarshalString(t, `{"definitions": {"x": {}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("http://example.com/schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := sch.Document()
+	want := unmarshalString(t, `{
+		"$schema": "http://json-schema.org/draft-04/schema",
+		"definitions": { "pos": { "type": "integer", "minimum": 0, "exclusiveMinimum": true } },
+		"properties": {
+			"a": { "$ref": "#/definitions/pos" },
+			"b": { "$ref": "http://example.com/other.json#/definitions/x" }
+		}
+	}`)
+	if !jsonEqual(t, got, want) {
+		b, _ := json.Marshal(got)
+		t.Fatalf("got %s", b)
+	}
+}
+
+// jsonEqual tells whether v1 and v2 are equal after json roundtrip.
+func jsonEqual(t *testing.T, v1, v2 any) bool {
+	t.Helper()
+	normalize := func(v any) any {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return unmarshalString(t, string(b))
+	}
+	return reflect.DeepEqual(normalize(v1), normalize(v2))
+}
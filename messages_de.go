@@ -0,0 +1,135 @@
+package jsonschema
+
+// deMessages are the german translations of error messages.
+var deMessages = map[string]string{
+	// kind --
+	"invalid jsonType %T":                     "ungültiger JSON-Typ %T",
+	"invalid jsonType %T: %v":                 "ungültiger JSON-Typ %T: %v",
+	"jsonschema validation failed with %s":    "jsonschema-Validierung fehlgeschlagen mit %s",
+	"validation failed":                       "Validierung fehlgeschlagen",
+	"not failed":                              "not fehlgeschlagen",
+	"allOf failed":                            "allOf fehlgeschlagen",
+	"anyOf failed":                            "anyOf fehlgeschlagen",
+	"oneOf failed, none matched":              "oneOf fehlgeschlagen, kein Teilschema passt",
+	"oneOf failed, subschemas %d, %d matched": "oneOf fehlgeschlagen, Teilschemas %d und %d passen",
+	"false schema":                            "Schema ist false",
+	"both %s and %s resolve to %q causing reference cycle": "%s und %s verweisen beide auf %q, was einen Referenzzyklus verursacht",
+	"got %s, want %s":                       "Typ ist %s, erwartet %s",
+	"cannot coerce %s to %s":                "%s kann nicht in %s umgewandelt werden",
+	"null is not allowed":                   "null ist nicht erlaubt",
+	"value must be %s":                      "Wert muss %s sein",
+	"value must be one of %s":               "Wert muss einer von %s sein",
+	"enum failed":                           "enum fehlgeschlagen",
+	"const failed":                          "const fehlgeschlagen",
+	"%s is not valid %s: %v":                "%s ist kein gültiges %s: %v",
+	"minProperties: got %d, want %d":        "minProperties: %d Eigenschaften, mindestens %d erwartet",
+	"maxProperties: got %d, want %d":        "maxProperties: %d Eigenschaften, höchstens %d erwartet",
+	"minItems: got %d, want %d":             "minItems: %d Elemente, mindestens %d erwartet",
+	"maxItems: got %d, want %d":             "maxItems: %d Elemente, höchstens %d erwartet",
+	"last %d additionalItem(s) not allowed": "die letzten %d zusätzlichen Elemente sind nicht erlaubt",
+	"missing property %s":                   "Eigenschaft %s fehlt",
+	"missing properties %s":                 "Eigenschaften %s fehlen",
+	"properties %s required, if %s exists":  "Eigenschaften %s sind erforderlich, wenn %s vorhanden ist",
+	"additional properties %s not allowed":  "zusätzliche Eigenschaften %s sind nicht erlaubt",
+	"invalid propertyName %s":               "ungültiger Eigenschaftsname %s",
+	"items at %d and %d are equal":          "Elemente an Position %d und %d sind gleich",
+	"no items match contains schema":        "kein Element passt zum contains-Schema",
+	"min %d items required to match contains schema, but none matched":           "mindestens %d Elemente müssen zum contains-Schema passen, aber keines passt",
+	"min %d items required to match contains schema, but matched %d items at %v": "mindestens %d Elemente müssen zum contains-Schema passen, aber nur %d Elemente an Position %v passen",
+	"max %d items required to match contains schema, but matched %d items at %v": "höchstens %d Elemente dürfen zum contains-Schema passen, aber %d Elemente an Position %v passen",
+	"minLength: got %d, want %d":            "minLength: Länge %d, mindestens %d erwartet",
+	"maxLength: got %d, want %d":            "maxLength: Länge %d, höchstens %d erwartet",
+	"%s does not match pattern %s":          "%s entspricht nicht dem Muster %s",
+	"value is not %s encoded: %v":           "Wert ist nicht %s-kodiert: %v",
+	"value if not of mediatype %s: %v":      "Wert ist nicht vom Medientyp %s: %v",
+	"contentSchema failed":                  "contentSchema fehlgeschlagen",
+	"minimum: got %v, want %v":              "minimum: Wert %v, mindestens %v erwartet",
+	"maximum: got %v, want %v":              "maximum: Wert %v, höchstens %v erwartet",
+	"exclusiveMinimum: got %v, want %v":     "exclusiveMinimum: Wert %v, größer als %v erwartet",
+	"exclusiveMaximum: got %v, want %v":     "exclusiveMaximum: Wert %v, kleiner als %v erwartet",
+	"multipleOf: got %v, want %v":           "multipleOf: Wert %v, Vielfaches von %v erwartet",
+	"dateMinimum: got %s, want %s":          "dateMinimum: Datum %s, frühestens %s erwartet",
+	"dateMaximum: got %s, want %s":          "dateMaximum: Datum %s, spätestens %s erwartet",
+	"dateExclusiveMinimum: got %s, want %s": "dateExclusiveMinimum: Datum %s, nach %s erwartet",
+	"dateExclusiveMaximum: got %s, want %s": "dateExclusiveMaximum: Datum %s, vor %s erwartet",
+	"order at %d and %d have same %s":       "order an Position %d und %d haben dasselbe %s",
+	"at %s":                                 "bei %s",
+
+	// format --
+	"%s contains non-digit":         "%s enthält eine Nicht-Ziffer",
+	"%s is empty":                   "%s ist leer",
+	"%s starts with zero":           "%s beginnt mit Null",
+	"11th character must be t or T": "11. Zeichen muss t oder T sein",
+	"backslash and quote are not allowed within quoted local part": "Backslash und Anführungszeichen sind im zitierten lokalen Teil nicht erlaubt",
+	"build identifier is empty":                                    "Build-Bezeichner ist leer",
+	"build is empty":                                               "Build ist leer",
+	"consecutive dots":                                             "aufeinanderfolgende Punkte",
+	"contains \\":                                                  "enthält \\",
+	"decimal must be between 0 and 255":                            "Dezimalzahl muss zwischen 0 und 255 liegen",
+	"element %d must be %d characters long":                        "Element %d muss %d Zeichen lang sein",
+	"ends with dot":                                                "endet mit Punkt",
+	"expected four decimals":                                       "vier Dezimalzahlen erwartet",
+	"hour/min in offset out of range":                              "Stunde/Minute im Offset außerhalb des Bereichs",
+	"hour/min/sec out of range":                                    "Stunde/Minute/Sekunde außerhalb des Bereichs",
+	"invalid character %q":                                         "ungültiges Zeichen %q",
+	"invalid character %q in build identifier":                     "ungültiges Zeichen %q im Build-Bezeichner",
+	"invalid character %q in pre-release identifier":               "ungültiges Zeichen %q im Pre-Release-Bezeichner",
+	"invalid date element: %v":                                     "ungültiges Datumselement: %v",
+	"invalid domain: %v":                                           "ungültige Domain: %v",
+	"invalid end date-time: %v":                                    "ungültiges End-Datum/Uhrzeit: %v",
+	"invalid end duration: %v":                                     "ungültige End-Dauer: %v",
+	"invalid hour/min in offset":                                   "ungültige Stunde/Minute im Offset",
+	"invalid hour/min/sec":                                         "ungültige Stunde/Minute/Sekunde",
+	"invalid ipv4 address: %v":                                     "ungültige ipv4-Adresse: %v",
+	"invalid ipv6 address: %v":                                     "ungültige ipv6-Adresse: %v",
+	"invalid leap second":                                          "ungültige Schaltsekunde",
+	"invalid start date-time: %v":                                  "ungültiges Start-Datum/Uhrzeit: %v",
+	"invalid start duration: %v":                                   "ungültige Start-Dauer: %v",
+	"invalid time element: %v":                                     "ungültiges Zeitelement: %v",
+	"invalid unit %q":                                              "ungültige Einheit %q",
+	"invalid week":                                                 "ungültige Woche",
+	"ipv6 address not enclosed in brackets":                        "ipv6-Adresse nicht in eckige Klammern eingeschlossen",
+	"label ends with hyphen":                                       "Label endet mit Bindestrich",
+	"label must be 1 to 63 characters long":                        "Label muss 1 bis 63 Zeichen lang sein",
+	"label starts with hyphen":                                     "Label beginnt mit Bindestrich",
+	"leading zeros":                                                "führende Nullen",
+	"less than 20 characters long":                                 "kürzer als 20 Zeichen",
+	"less than 9 characters long":                                  "kürzer als 9 Zeichen",
+	"local part more than 64 characters long":                      "lokaler Teil länger als 64 Zeichen",
+	"missing @":                                                    "@ fehlt",
+	"missing colon":                                                "Doppelpunkt fehlt",
+	"missing colon in correct place":                               "Doppelpunkt fehlt an der richtigen Stelle",
+	"missing colon in offset in correct place":                     "Doppelpunkt im Offset fehlt an der richtigen Stelle",
+	"missing hour/min/sec":                                         "Stunde/Minute/Sekunde fehlt",
+	"missing number":                                               "Zahl fehlt",
+	"missing slash":                                                "Schrägstrich fehlt",
+	"missing unit":                                                 "Einheit fehlt",
+	"more than 253 characters long":                                "länger als 253 Zeichen",
+	"more than 255 characters long":                                "länger als 255 Zeichen",
+	"more than one T":                                              "mehr als ein T",
+	"must have %d elements":                                        "muss %d Elemente haben",
+	"must start with P":                                            "muss mit P beginnen",
+	"must start with non-negative integer":                         "muss mit einer nicht-negativen ganzen Zahl beginnen",
+	"nested curly braces":                                          "verschachtelte geschweifte Klammern",
+	"no digits in second fraction":                                 "keine Ziffern im Sekundenbruchteil",
+	"no matching closing brace":                                    "keine passende schließende Klammer",
+	"no number in week":                                            "keine Zahl in Woche",
+	"no time elements":                                             "keine Zeitelemente",
+	"non-hex character %q":                                         "Nicht-Hex-Zeichen %q",
+	"non-positive hour/min in offset":                              "nicht-positive Stunde/Minute im Offset",
+	"non-positive hour/min/sec":                                    "nicht-positive Stunde/Minute/Sekunde",
+	"not starting with /":                                          "beginnt nicht mit /",
+	"nothing after P":                                              "nichts nach P",
+	"offset must be 6 characters long":                             "Offset muss 6 Zeichen lang sein",
+	"offset must begin with plus/minus":                            "Offset muss mit Plus/Minus beginnen",
+	"percent decode failed: %v":                                    "Prozent-Dekodierung fehlgeschlagen: %v",
+	"pre-release identifier is empty":                              "Pre-Release-Bezeichner ist leer",
+	"pre-release numeric identifier starts with zero":              "numerischer Pre-Release-Bezeichner beginnt mit Null",
+	"relative url":                                                 "relative URL",
+	"starts with dot":                                              "beginnt mit Punkt",
+	"starts with zero":                                             "beginnt mit Null",
+	"unit %q out of order":                                         "Einheit %q in falscher Reihenfolge",
+	"versionCore must have 3 numbers separated by dot":             "versionCore muss aus 3 durch Punkt getrennten Zahlen bestehen",
+	"zone id is not a part of ipv6 address":                        "Zonen-ID ist kein Teil einer ipv6-Adresse",
+	"~ must be followed by 0 or 1":                                 "auf ~ muss 0 oder 1 folgen",
+}
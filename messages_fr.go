@@ -0,0 +1,135 @@
+package jsonschema
+
+// frMessages are the french translations of error messages.
+var frMessages = map[string]string{
+	// kind --
+	"invalid jsonType %T":                     "type JSON invalide %T",
+	"invalid jsonType %T: %v":                 "type JSON invalide %T : %v",
+	"jsonschema validation failed with %s":    "échec de la validation jsonschema avec %s",
+	"validation failed":                       "échec de la validation",
+	"not failed":                              "échec de not",
+	"allOf failed":                            "échec de allOf",
+	"anyOf failed":                            "échec de anyOf",
+	"oneOf failed, none matched":              "échec de oneOf, aucun sous-schéma ne correspond",
+	"oneOf failed, subschemas %d, %d matched": "échec de oneOf, les sous-schémas %d et %d correspondent",
+	"false schema":                            "schéma false",
+	"both %s and %s resolve to %q causing reference cycle": "%s et %s se résolvent tous deux en %q, ce qui crée un cycle de références",
+	"got %s, want %s":                       "type %s, %s attendu",
+	"cannot coerce %s to %s":                "impossible de convertir %s en %s",
+	"null is not allowed":                   "null n'est pas autorisé",
+	"value must be %s":                      "la valeur doit être %s",
+	"value must be one of %s":               "la valeur doit être l'une de %s",
+	"enum failed":                           "échec de enum",
+	"const failed":                          "échec de const",
+	"%s is not valid %s: %v":                "%s n'est pas un %s valide : %v",
+	"minProperties: got %d, want %d":        "minProperties : %d propriétés, au moins %d attendues",
+	"maxProperties: got %d, want %d":        "maxProperties : %d propriétés, au plus %d attendues",
+	"minItems: got %d, want %d":             "minItems : %d éléments, au moins %d attendus",
+	"maxItems: got %d, want %d":             "maxItems : %d éléments, au plus %d attendus",
+	"last %d additionalItem(s) not allowed": "les %d derniers éléments supplémentaires ne sont pas autorisés",
+	"missing property %s":                   "propriété %s manquante",
+	"missing properties %s":                 "propriétés %s manquantes",
+	"properties %s required, if %s exists":  "les propriétés %s sont requises si %s existe",
+	"additional properties %s not allowed":  "les propriétés supplémentaires %s ne sont pas autorisées",
+	"invalid propertyName %s":               "nom de propriété %s invalide",
+	"items at %d and %d are equal":          "les éléments aux positions %d et %d sont égaux",
+	"no items match contains schema":        "aucun élément ne correspond au schéma contains",
+	"min %d items required to match contains schema, but none matched":           "au moins %d éléments doivent correspondre au schéma contains, mais aucun ne correspond",
+	"min %d items required to match contains schema, but matched %d items at %v": "au moins %d éléments doivent correspondre au schéma contains, mais seuls %d éléments aux positions %v correspondent",
+	"max %d items required to match contains schema, but matched %d items at %v": "au plus %d éléments peuvent correspondre au schéma contains, mais %d éléments aux positions %v correspondent",
+	"minLength: got %d, want %d":            "minLength : longueur %d, au moins %d attendue",
+	"maxLength: got %d, want %d":            "maxLength : longueur %d, au plus %d attendue",
+	"%s does not match pattern %s":          "%s ne correspond pas au motif %s",
+	"value is not %s encoded: %v":           "la valeur n'est pas encodée en %s : %v",
+	"value if not of mediatype %s: %v":      "la valeur n'est pas du type de média %s : %v",
+	"contentSchema failed":                  "échec de contentSchema",
+	"minimum: got %v, want %v":              "minimum : valeur %v, au moins %v attendu",
+	"maximum: got %v, want %v":              "maximum : valeur %v, au plus %v attendu",
+	"exclusiveMinimum: got %v, want %v":     "exclusiveMinimum : valeur %v, supérieure à %v attendue",
+	"exclusiveMaximum: got %v, want %v":     "exclusiveMaximum : valeur %v, inférieure à %v attendue",
+	"multipleOf: got %v, want %v":           "multipleOf : valeur %v, multiple de %v attendu",
+	"dateMinimum: got %s, want %s":          "dateMinimum : date %s, au plus tôt %s attendue",
+	"dateMaximum: got %s, want %s":          "dateMaximum : date %s, au plus tard %s attendue",
+	"dateExclusiveMinimum: got %s, want %s": "dateExclusiveMinimum : date %s, postérieure à %s attendue",
+	"dateExclusiveMaximum: got %s, want %s": "dateExclusiveMaximum : date %s, antérieure à %s attendue",
+	"order at %d and %d have same %s":       "les order aux positions %d et %d ont le même %s",
+	"at %s":                                 "à %s",
+
+	// format --
+	"%s contains non-digit":         "%s contient un caractère non numérique",
+	"%s is empty":                   "%s est vide",
+	"%s starts with zero":           "%s commence par zéro",
+	"11th character must be t or T": "le 11e caractère doit être t ou T",
+	"backslash and quote are not allowed within quoted local part": "la barre oblique inverse et les guillemets ne sont pas autorisés dans la partie locale entre guillemets",
+	"build identifier is empty":                                    "l'identifiant de build est vide",
+	"build is empty":                                               "le build est vide",
+	"consecutive dots":                                             "points consécutifs",
+	"contains \\":                                                  "contient \\",
+	"decimal must be between 0 and 255":                            "le nombre décimal doit être compris entre 0 et 255",
+	"element %d must be %d characters long":                        "l'élément %d doit comporter %d caractères",
+	"ends with dot":                                                "se termine par un point",
+	"expected four decimals":                                       "quatre nombres décimaux attendus",
+	"hour/min in offset out of range":                              "heure/minute du décalage hors limites",
+	"hour/min/sec out of range":                                    "heure/minute/seconde hors limites",
+	"invalid character %q":                                         "caractère %q invalide",
+	"invalid character %q in build identifier":                     "caractère %q invalide dans l'identifiant de build",
+	"invalid character %q in pre-release identifier":               "caractère %q invalide dans l'identifiant de pré-version",
+	"invalid date element: %v":                                     "élément de date invalide : %v",
+	"invalid domain: %v":                                           "domaine invalide : %v",
+	"invalid end date-time: %v":                                    "date-heure de fin invalide : %v",
+	"invalid end duration: %v":                                     "durée de fin invalide : %v",
+	"invalid hour/min in offset":                                   "heure/minute du décalage invalide",
+	"invalid hour/min/sec":                                         "heure/minute/seconde invalide",
+	"invalid ipv4 address: %v":                                     "adresse ipv4 invalide : %v",
+	"invalid ipv6 address: %v":                                     "adresse ipv6 invalide : %v",
+	"invalid leap second":                                          "seconde intercalaire invalide",
+	"invalid start date-time: %v":                                  "date-heure de début invalide : %v",
+	"invalid start duration: %v":                                   "durée de début invalide : %v",
+	"invalid time element: %v":                                     "élément d'heure invalide : %v",
+	"invalid unit %q":                                              "unité %q invalide",
+	"invalid week":                                                 "semaine invalide",
+	"ipv6 address not enclosed in brackets":                        "adresse ipv6 non entourée de crochets",
+	"label ends with hyphen":                                       "le libellé se termine par un trait d'union",
+	"label must be 1 to 63 characters long":                        "le libellé doit comporter de 1 à 63 caractères",
+	"label starts with hyphen":                                     "le libellé commence par un trait d'union",
+	"leading zeros":                                                "zéros initiaux",
+	"less than 20 characters long":                                 "moins de 20 caractères",
+	"less than 9 characters long":                                  "moins de 9 caractères",
+	"local part more than 64 characters long":                      "partie locale de plus de 64 caractères",
+	"missing @":                                                    "@ manquant",
+	"missing colon":                                                "deux-points manquant",
+	"missing colon in correct place":                               "deux-points manquant au bon endroit",
+	"missing colon in offset in correct place":                     "deux-points manquant au bon endroit dans le décalage",
+	"missing hour/min/sec":                                         "heure/minute/seconde manquante",
+	"missing number":                                               "nombre manquant",
+	"missing slash":                                                "barre oblique manquante",
+	"missing unit":                                                 "unité manquante",
+	"more than 253 characters long":                                "plus de 253 caractères",
+	"more than 255 characters long":                                "plus de 255 caractères",
+	"more than one T":                                              "plus d'un T",
+	"must have %d elements":                                        "doit comporter %d éléments",
+	"must start with P":                                            "doit commencer par P",
+	"must start with non-negative integer":                         "doit commencer par un entier non négatif",
+	"nested curly braces":                                          "accolades imbriquées",
+	"no digits in second fraction":                                 "aucun chiffre dans la fraction de seconde",
+	"no matching closing brace":                                    "aucune accolade fermante correspondante",
+	"no number in week":                                            "aucun nombre dans la semaine",
+	"no time elements":                                             "aucun élément d'heure",
+	"non-hex character %q":                                         "caractère non hexadécimal %q",
+	"non-positive hour/min in offset":                              "heure/minute du décalage non positive",
+	"non-positive hour/min/sec":                                    "heure/minute/seconde non positive",
+	"not starting with /":                                          "ne commence pas par /",
+	"nothing after P":                                              "rien après P",
+	"offset must be 6 characters long":                             "le décalage doit comporter 6 caractères",
+	"offset must begin with plus/minus":                            "le décalage doit commencer par plus/moins",
+	"percent decode failed: %v":                                    "échec du décodage pourcent : %v",
+	"pre-release identifier is empty":                              "l'identifiant de pré-version est vide",
+	"pre-release numeric identifier starts with zero":              "l'identifiant numérique de pré-version commence par zéro",
+	"relative url":                                                 "url relative",
+	"starts with dot":                                              "commence par un point",
+	"starts with zero":                                             "commence par zéro",
+	"unit %q out of order":                                         "unité %q dans le désordre",
+	"versionCore must have 3 numbers separated by dot":             "versionCore doit comporter 3 nombres séparés par des points",
+	"zone id is not a part of ipv6 address":                        "l'identifiant de zone ne fait pas partie d'une adresse ipv6",
+	"~ must be followed by 0 or 1":                                 "~ doit être suivi de 0 ou 1",
+}
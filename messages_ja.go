@@ -0,0 +1,135 @@
+package jsonschema
+
+// jaMessages are the japanese translations of error messages.
+var jaMessages = map[string]string{
+	// kind --
+	"invalid jsonType %T":                     "無効な JSON 型 %T",
+	"invalid jsonType %T: %v":                 "無効な JSON 型 %T: %v",
+	"jsonschema validation failed with %s":    "jsonschema の検証に失敗しました: %s",
+	"validation failed":                       "検証に失敗しました",
+	"not failed":                              "not の検証に失敗しました",
+	"allOf failed":                            "allOf の検証に失敗しました",
+	"anyOf failed":                            "anyOf の検証に失敗しました",
+	"oneOf failed, none matched":              "oneOf の検証に失敗しました。一致するサブスキーマがありません",
+	"oneOf failed, subschemas %d, %d matched": "oneOf の検証に失敗しました。サブスキーマ %d と %d の両方が一致しました",
+	"false schema":                            "スキーマが false です",
+	"both %s and %s resolve to %q causing reference cycle": "%s と %s がともに %q に解決され、参照が循環しています",
+	"got %s, want %s":                       "型が %s です。%s である必要があります",
+	"cannot coerce %s to %s":                "%s を %s に変換できません",
+	"null is not allowed":                   "null は許可されていません",
+	"value must be %s":                      "値は %s である必要があります",
+	"value must be one of %s":               "値は %s のいずれかである必要があります",
+	"enum failed":                           "enum の検証に失敗しました",
+	"const failed":                          "const の検証に失敗しました",
+	"%s is not valid %s: %v":                "%s は有効な %s ではありません: %v",
+	"minProperties: got %d, want %d":        "minProperties: プロパティ数は %d です。%d 以上である必要があります",
+	"maxProperties: got %d, want %d":        "maxProperties: プロパティ数は %d です。%d 以下である必要があります",
+	"minItems: got %d, want %d":             "minItems: 要素数は %d です。%d 以上である必要があります",
+	"maxItems: got %d, want %d":             "maxItems: 要素数は %d です。%d 以下である必要があります",
+	"last %d additionalItem(s) not allowed": "最後の %d 個の追加要素は許可されていません",
+	"missing property %s":                   "プロパティ %s がありません",
+	"missing properties %s":                 "プロパティ %s がありません",
+	"properties %s required, if %s exists":  "%[2]s が存在する場合、プロパティ %[1]s は必須です",
+	"additional properties %s not allowed":  "追加プロパティ %s は許可されていません",
+	"invalid propertyName %s":               "無効なプロパティ名 %s",
+	"items at %d and %d are equal":          "位置 %d と %d の要素が等しいです",
+	"no items match contains schema":        "contains スキーマに一致する要素がありません",
+	"min %d items required to match contains schema, but none matched":           "contains スキーマに %d 個以上の要素が一致する必要がありますが、一致する要素がありません",
+	"min %d items required to match contains schema, but matched %d items at %v": "contains スキーマに %d 個以上の要素が一致する必要がありますが、%d 個の要素 (位置 %v) のみが一致しました",
+	"max %d items required to match contains schema, but matched %d items at %v": "contains スキーマに一致する要素は %d 個以下である必要がありますが、%d 個の要素 (位置 %v) が一致しました",
+	"minLength: got %d, want %d":            "minLength: 長さは %d です。%d 以上である必要があります",
+	"maxLength: got %d, want %d":            "maxLength: 長さは %d です。%d 以下である必要があります",
+	"%s does not match pattern %s":          "%s はパターン %s に一致しません",
+	"value is not %s encoded: %v":           "値は %s でエンコードされていません: %v",
+	"value if not of mediatype %s: %v":      "値はメディアタイプ %s ではありません: %v",
+	"contentSchema failed":                  "contentSchema の検証に失敗しました",
+	"minimum: got %v, want %v":              "minimum: 値は %v です。%v 以上である必要があります",
+	"maximum: got %v, want %v":              "maximum: 値は %v です。%v 以下である必要があります",
+	"exclusiveMinimum: got %v, want %v":     "exclusiveMinimum: 値は %v です。%v より大きい必要があります",
+	"exclusiveMaximum: got %v, want %v":     "exclusiveMaximum: 値は %v です。%v より小さい必要があります",
+	"multipleOf: got %v, want %v":           "multipleOf: 値は %v です。%v の倍数である必要があります",
+	"dateMinimum: got %s, want %s":          "dateMinimum: 日付は %s です。%s 以降である必要があります",
+	"dateMaximum: got %s, want %s":          "dateMaximum: 日付は %s です。%s 以前である必要があります",
+	"dateExclusiveMinimum: got %s, want %s": "dateExclusiveMinimum: 日付は %s です。%s より後である必要があります",
+	"dateExclusiveMaximum: got %s, want %s": "dateExclusiveMaximum: 日付は %s です。%s より前である必要があります",
+	"order at %d and %d have same %s":       "位置 %d と %d の order が同じ %s を持っています",
+	"at %s":                                 "%s にて",
+
+	// format --
+	"%s contains non-digit":         "%s に数字以外の文字が含まれています",
+	"%s is empty":                   "%s が空です",
+	"%s starts with zero":           "%s がゼロで始まっています",
+	"11th character must be t or T": "11 文字目は t または T である必要があります",
+	"backslash and quote are not allowed within quoted local part": "引用符で囲まれたローカル部にバックスラッシュと引用符は使用できません",
+	"build identifier is empty":                                    "ビルド識別子が空です",
+	"build is empty":                                               "ビルドが空です",
+	"consecutive dots":                                             "ドットが連続しています",
+	"contains \\":                                                  "\\ が含まれています",
+	"decimal must be between 0 and 255":                            "10 進数は 0 から 255 の間である必要があります",
+	"element %d must be %d characters long":                        "要素 %d は %d 文字である必要があります",
+	"ends with dot":                                                "ドットで終わっています",
+	"expected four decimals":                                       "4 つの 10 進数が必要です",
+	"hour/min in offset out of range":                              "オフセットの時/分が範囲外です",
+	"hour/min/sec out of range":                                    "時/分/秒が範囲外です",
+	"invalid character %q":                                         "無効な文字 %q",
+	"invalid character %q in build identifier":                     "ビルド識別子に無効な文字 %q があります",
+	"invalid character %q in pre-release identifier":               "プレリリース識別子に無効な文字 %q があります",
+	"invalid date element: %v":                                     "無効な日付要素: %v",
+	"invalid domain: %v":                                           "無効なドメイン: %v",
+	"invalid end date-time: %v":                                    "無効な終了日時: %v",
+	"invalid end duration: %v":                                     "無効な終了期間: %v",
+	"invalid hour/min in offset":                                   "オフセットの時/分が無効です",
+	"invalid hour/min/sec":                                         "時/分/秒が無効です",
+	"invalid ipv4 address: %v":                                     "無効な ipv4 アドレス: %v",
+	"invalid ipv6 address: %v":                                     "無効な ipv6 アドレス: %v",
+	"invalid leap second":                                          "無効なうるう秒",
+	"invalid start date-time: %v":                                  "無効な開始日時: %v",
+	"invalid start duration: %v":                                   "無効な開始期間: %v",
+	"invalid time element: %v":                                     "無効な時刻要素: %v",
+	"invalid unit %q":                                              "無効な単位 %q",
+	"invalid week":                                                 "無効な週",
+	"ipv6 address not enclosed in brackets":                        "ipv6 アドレスが角括弧で囲まれていません",
+	"label ends with hyphen":                                       "ラベルがハイフンで終わっています",
+	"label must be 1 to 63 characters long":                        "ラベルは 1 から 63 文字である必要があります",
+	"label starts with hyphen":                                     "ラベルがハイフンで始まっています",
+	"leading zeros":                                                "先頭にゼロがあります",
+	"less than 20 characters long":                                 "20 文字未満です",
+	"less than 9 characters long":                                  "9 文字未満です",
+	"local part more than 64 characters long":                      "ローカル部が 64 文字を超えています",
+	"missing @":                                                    "@ がありません",
+	"missing colon":                                                "コロンがありません",
+	"missing colon in correct place":                               "正しい位置にコロンがありません",
+	"missing colon in offset in correct place":                     "オフセットの正しい位置にコロンがありません",
+	"missing hour/min/sec":                                         "時/分/秒がありません",
+	"missing number":                                               "数値がありません",
+	"missing slash":                                                "スラッシュがありません",
+	"missing unit":                                                 "単位がありません",
+	"more than 253 characters long":                                "253 文字を超えています",
+	"more than 255 characters long":                                "255 文字を超えています",
+	"more than one T":                                              "T が複数あります",
+	"must have %d elements":                                        "%d 個の要素が必要です",
+	"must start with P":                                            "P で始まる必要があります",
+	"must start with non-negative integer":                         "非負の整数で始まる必要があります",
+	"nested curly braces":                                          "波括弧が入れ子になっています",
+	"no digits in second fraction":                                 "秒の小数部に数字がありません",
+	"no matching closing brace":                                    "対応する閉じ波括弧がありません",
+	"no number in week":                                            "週に数値がありません",
+	"no time elements":                                             "時刻要素がありません",
+	"non-hex character %q":                                         "16 進数以外の文字 %q",
+	"non-positive hour/min in offset":                              "オフセットの時/分が正の数ではありません",
+	"non-positive hour/min/sec":                                    "時/分/秒が正の数ではありません",
+	"not starting with /":                                          "/ で始まっていません",
+	"nothing after P":                                              "P の後に何もありません",
+	"offset must be 6 characters long":                             "オフセットは 6 文字である必要があります",
+	"offset must begin with plus/minus":                            "オフセットはプラスまたはマイナスで始まる必要があります",
+	"percent decode failed: %v":                                    "パーセントデコードに失敗しました: %v",
+	"pre-release identifier is empty":                              "プレリリース識別子が空です",
+	"pre-release numeric identifier starts with zero":              "プレリリースの数値識別子がゼロで始まっています",
+	"relative url":                                                 "相対 url です",
+	"starts with dot":                                              "ドットで始まっています",
+	"starts with zero":                                             "ゼロで始まっています",
+	"unit %q out of order":                                         "単位 %q の順序が正しくありません",
+	"versionCore must have 3 numbers separated by dot":             "versionCore はドットで区切られた 3 つの数値である必要があります",
+	"zone id is not a part of ipv6 address":                        "ゾーン id は ipv6 アドレスの一部ではありません",
+	"~ must be followed by 0 or 1":                                 "~ の後には 0 または 1 が必要です",
+}
@@ -0,0 +1,135 @@
+package jsonschema
+
+// zhHansMessages are the simplified chinese translations of error messages.
+var zhHansMessages = map[string]string{
+	// kind --
+	"invalid jsonType %T":                     "无效的 JSON 类型 %T",
+	"invalid jsonType %T: %v":                 "无效的 JSON 类型 %T：%v",
+	"jsonschema validation failed with %s":    "jsonschema 验证失败：%s",
+	"validation failed":                       "验证失败",
+	"not failed":                              "not 验证失败",
+	"allOf failed":                            "allOf 验证失败",
+	"anyOf failed":                            "anyOf 验证失败",
+	"oneOf failed, none matched":              "oneOf 验证失败，没有匹配的子模式",
+	"oneOf failed, subschemas %d, %d matched": "oneOf 验证失败，子模式 %d 和 %d 均匹配",
+	"false schema":                            "模式为 false",
+	"both %s and %s resolve to %q causing reference cycle": "%s 和 %s 都解析为 %q，导致循环引用",
+	"got %s, want %s":                       "类型为 %s，应为 %s",
+	"cannot coerce %s to %s":                "无法将 %s 转换为 %s",
+	"null is not allowed":                   "不允许为 null",
+	"value must be %s":                      "值必须为 %s",
+	"value must be one of %s":               "值必须为 %s 之一",
+	"enum failed":                           "enum 验证失败",
+	"const failed":                          "const 验证失败",
+	"%s is not valid %s: %v":                "%s 不是有效的 %s：%v",
+	"minProperties: got %d, want %d":        "minProperties：属性数为 %d，至少应为 %d",
+	"maxProperties: got %d, want %d":        "maxProperties：属性数为 %d，至多应为 %d",
+	"minItems: got %d, want %d":             "minItems：元素数为 %d，至少应为 %d",
+	"maxItems: got %d, want %d":             "maxItems：元素数为 %d，至多应为 %d",
+	"last %d additionalItem(s) not allowed": "不允许最后 %d 个额外元素",
+	"missing property %s":                   "缺少属性 %s",
+	"missing properties %s":                 "缺少属性 %s",
+	"properties %s required, if %s exists":  "存在 %[2]s 时，属性 %[1]s 是必需的",
+	"additional properties %s not allowed":  "不允许额外属性 %s",
+	"invalid propertyName %s":               "无效的属性名 %s",
+	"items at %d and %d are equal":          "位置 %d 和 %d 的元素相同",
+	"no items match contains schema":        "没有元素匹配 contains 模式",
+	"min %d items required to match contains schema, but none matched":           "至少需要 %d 个元素匹配 contains 模式，但没有元素匹配",
+	"min %d items required to match contains schema, but matched %d items at %v": "至少需要 %d 个元素匹配 contains 模式，但只有 %d 个元素匹配，位置为 %v",
+	"max %d items required to match contains schema, but matched %d items at %v": "至多允许 %d 个元素匹配 contains 模式，但有 %d 个元素匹配，位置为 %v",
+	"minLength: got %d, want %d":            "minLength：长度为 %d，至少应为 %d",
+	"maxLength: got %d, want %d":            "maxLength：长度为 %d，至多应为 %d",
+	"%s does not match pattern %s":          "%s 不匹配模式 %s",
+	"value is not %s encoded: %v":           "值不是 %s 编码：%v",
+	"value if not of mediatype %s: %v":      "值不是 %s 媒体类型：%v",
+	"contentSchema failed":                  "contentSchema 验证失败",
+	"minimum: got %v, want %v":              "minimum：值为 %v，至少应为 %v",
+	"maximum: got %v, want %v":              "maximum：值为 %v，至多应为 %v",
+	"exclusiveMinimum: got %v, want %v":     "exclusiveMinimum：值为 %v，应大于 %v",
+	"exclusiveMaximum: got %v, want %v":     "exclusiveMaximum：值为 %v，应小于 %v",
+	"multipleOf: got %v, want %v":           "multipleOf：值为 %v，应为 %v 的倍数",
+	"dateMinimum: got %s, want %s":          "dateMinimum：日期为 %s，不应早于 %s",
+	"dateMaximum: got %s, want %s":          "dateMaximum：日期为 %s，不应晚于 %s",
+	"dateExclusiveMinimum: got %s, want %s": "dateExclusiveMinimum：日期为 %s，应晚于 %s",
+	"dateExclusiveMaximum: got %s, want %s": "dateExclusiveMaximum：日期为 %s，应早于 %s",
+	"order at %d and %d have same %s":       "位置 %d 和 %d 的 order 具有相同的 %s",
+	"at %s":                                 "位于 %s",
+
+	// format --
+	"%s contains non-digit":         "%s 包含非数字字符",
+	"%s is empty":                   "%s 为空",
+	"%s starts with zero":           "%s 以零开头",
+	"11th character must be t or T": "第 11 个字符必须为 t 或 T",
+	"backslash and quote are not allowed within quoted local part": "带引号的本地部分不允许包含反斜杠和引号",
+	"build identifier is empty":                                    "构建标识符为空",
+	"build is empty":                                               "构建信息为空",
+	"consecutive dots":                                             "包含连续的点",
+	"contains \\":                                                  "包含 \\",
+	"decimal must be between 0 and 255":                            "十进制数必须在 0 到 255 之间",
+	"element %d must be %d characters long":                        "第 %d 个元素的长度必须为 %d 个字符",
+	"ends with dot":                                                "以点结尾",
+	"expected four decimals":                                       "应为四个十进制数",
+	"hour/min in offset out of range":                              "偏移量中的时/分超出范围",
+	"hour/min/sec out of range":                                    "时/分/秒超出范围",
+	"invalid character %q":                                         "无效字符 %q",
+	"invalid character %q in build identifier":                     "构建标识符中包含无效字符 %q",
+	"invalid character %q in pre-release identifier":               "预发布标识符中包含无效字符 %q",
+	"invalid date element: %v":                                     "无效的日期部分：%v",
+	"invalid domain: %v":                                           "无效的域名：%v",
+	"invalid end date-time: %v":                                    "无效的结束日期时间：%v",
+	"invalid end duration: %v":                                     "无效的结束时长：%v",
+	"invalid hour/min in offset":                                   "偏移量中的时/分无效",
+	"invalid hour/min/sec":                                         "时/分/秒无效",
+	"invalid ipv4 address: %v":                                     "无效的 ipv4 地址：%v",
+	"invalid ipv6 address: %v":                                     "无效的 ipv6 地址：%v",
+	"invalid leap second":                                          "无效的闰秒",
+	"invalid start date-time: %v":                                  "无效的开始日期时间：%v",
+	"invalid start duration: %v":                                   "无效的开始时长：%v",
+	"invalid time element: %v":                                     "无效的时间部分：%v",
+	"invalid unit %q":                                              "无效的单位 %q",
+	"invalid week":                                                 "无效的周",
+	"ipv6 address not enclosed in brackets":                        "ipv6 地址未用方括号括起",
+	"label ends with hyphen":                                       "标签以连字符结尾",
+	"label must be 1 to 63 characters long":                        "标签长度必须为 1 到 63 个字符",
+	"label starts with hyphen":                                     "标签以连字符开头",
+	"leading zeros":                                                "包含前导零",
+	"less than 20 characters long":                                 "长度少于 20 个字符",
+	"less than 9 characters long":                                  "长度少于 9 个字符",
+	"local part more than 64 characters long":                      "本地部分长度超过 64 个字符",
+	"missing @":                                                    "缺少 @",
+	"missing colon":                                                "缺少冒号",
+	"missing colon in correct place":                               "正确位置缺少冒号",
+	"missing colon in offset in correct place":                     "偏移量的正确位置缺少冒号",
+	"missing hour/min/sec":                                         "缺少时/分/秒",
+	"missing number":                                               "缺少数字",
+	"missing slash":                                                "缺少斜杠",
+	"missing unit":                                                 "缺少单位",
+	"more than 253 characters long":                                "长度超过 253 个字符",
+	"more than 255 characters long":                                "长度超过 255 个字符",
+	"more than one T":                                              "包含多个 T",
+	"must have %d elements":                                        "必须包含 %d 个部分",
+	"must start with P":                                            "必须以 P 开头",
+	"must start with non-negative integer":                         "必须以非负整数开头",
+	"nested curly braces":                                          "嵌套的花括号",
+	"no digits in second fraction":                                 "秒的小数部分没有数字",
+	"no matching closing brace":                                    "没有匹配的右花括号",
+	"no number in week":                                            "周中没有数字",
+	"no time elements":                                             "没有时间部分",
+	"non-hex character %q":                                         "非十六进制字符 %q",
+	"non-positive hour/min in offset":                              "偏移量中的时/分不是正数",
+	"non-positive hour/min/sec":                                    "时/分/秒不是正数",
+	"not starting with /":                                          "不以 / 开头",
+	"nothing after P":                                              "P 之后没有内容",
+	"offset must be 6 characters long":                             "偏移量长度必须为 6 个字符",
+	"offset must begin with plus/minus":                            "偏移量必须以加号或减号开头",
+	"percent decode failed: %v":                                    "百分号解码失败：%v",
+	"pre-release identifier is empty":                              "预发布标识符为空",
+	"pre-release numeric identifier starts with zero":              "预发布数字标识符以零开头",
+	"relative url":                                                 "相对 url",
+	"starts with dot":                                              "以点开头",
+	"starts with zero":                                             "以零开头",
+	"unit %q out of order":                                         "单位 %q 顺序错误",
+	"versionCore must have 3 numbers separated by dot":             "versionCore 必须是以点分隔的 3 个数字",
+	"zone id is not a part of ipv6 address":                        "区域 id 不是 ipv6 地址的一部分",
+	"~ must be followed by 0 or 1":                                 "~ 后面必须跟 0 或 1",
+}
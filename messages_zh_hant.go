@@ -0,0 +1,135 @@
+package jsonschema
+
+// zhHantMessages are the traditional chinese translations of error messages.
+var zhHantMessages = map[string]string{
+	// kind --
+	"invalid jsonType %T":                     "無效的 JSON 型別 %T",
+	"invalid jsonType %T: %v":                 "無效的 JSON 型別 %T：%v",
+	"jsonschema validation failed with %s":    "jsonschema 驗證失敗：%s",
+	"validation failed":                       "驗證失敗",
+	"not failed":                              "not 驗證失敗",
+	"allOf failed":                            "allOf 驗證失敗",
+	"anyOf failed":                            "anyOf 驗證失敗",
+	"oneOf failed, none matched":              "oneOf 驗證失敗，沒有符合的子綱要",
+	"oneOf failed, subschemas %d, %d matched": "oneOf 驗證失敗，子綱要 %d 和 %d 皆符合",
+	"false schema":                            "綱要為 false",
+	"both %s and %s resolve to %q causing reference cycle": "%s 和 %s 都解析為 %q，導致循環參照",
+	"got %s, want %s":                       "型別為 %s，應為 %s",
+	"cannot coerce %s to %s":                "無法將 %s 轉換為 %s",
+	"null is not allowed":                   "不允許為 null",
+	"value must be %s":                      "值必須為 %s",
+	"value must be one of %s":               "值必須為 %s 之一",
+	"enum failed":                           "enum 驗證失敗",
+	"const failed":                          "const 驗證失敗",
+	"%s is not valid %s: %v":                "%s 不是有效的 %s：%v",
+	"minProperties: got %d, want %d":        "minProperties：屬性數為 %d，至少應為 %d",
+	"maxProperties: got %d, want %d":        "maxProperties：屬性數為 %d，至多應為 %d",
+	"minItems: got %d, want %d":             "minItems：元素數為 %d，至少應為 %d",
+	"maxItems: got %d, want %d":             "maxItems：元素數為 %d，至多應為 %d",
+	"last %d additionalItem(s) not allowed": "不允許最後 %d 個額外元素",
+	"missing property %s":                   "缺少屬性 %s",
+	"missing properties %s":                 "缺少屬性 %s",
+	"properties %s required, if %s exists":  "存在 %[2]s 時，屬性 %[1]s 為必要",
+	"additional properties %s not allowed":  "不允許額外屬性 %s",
+	"invalid propertyName %s":               "無效的屬性名稱 %s",
+	"items at %d and %d are equal":          "位置 %d 和 %d 的元素相同",
+	"no items match contains schema":        "沒有元素符合 contains 綱要",
+	"min %d items required to match contains schema, but none matched":           "至少需要 %d 個元素符合 contains 綱要，但沒有元素符合",
+	"min %d items required to match contains schema, but matched %d items at %v": "至少需要 %d 個元素符合 contains 綱要，但只有 %d 個元素符合，位置為 %v",
+	"max %d items required to match contains schema, but matched %d items at %v": "至多允許 %d 個元素符合 contains 綱要，但有 %d 個元素符合，位置為 %v",
+	"minLength: got %d, want %d":            "minLength：長度為 %d，至少應為 %d",
+	"maxLength: got %d, want %d":            "maxLength：長度為 %d，至多應為 %d",
+	"%s does not match pattern %s":          "%s 不符合模式 %s",
+	"value is not %s encoded: %v":           "值不是 %s 編碼：%v",
+	"value if not of mediatype %s: %v":      "值不是 %s 媒體類型：%v",
+	"contentSchema failed":                  "contentSchema 驗證失敗",
+	"minimum: got %v, want %v":              "minimum：值為 %v，至少應為 %v",
+	"maximum: got %v, want %v":              "maximum：值為 %v，至多應為 %v",
+	"exclusiveMinimum: got %v, want %v":     "exclusiveMinimum：值為 %v，應大於 %v",
+	"exclusiveMaximum: got %v, want %v":     "exclusiveMaximum：值為 %v，應小於 %v",
+	"multipleOf: got %v, want %v":           "multipleOf：值為 %v，應為 %v 的倍數",
+	"dateMinimum: got %s, want %s":          "dateMinimum：日期為 %s，不應早於 %s",
+	"dateMaximum: got %s, want %s":          "dateMaximum：日期為 %s，不應晚於 %s",
+	"dateExclusiveMinimum: got %s, want %s": "dateExclusiveMinimum：日期為 %s，應晚於 %s",
+	"dateExclusiveMaximum: got %s, want %s": "dateExclusiveMaximum：日期為 %s，應早於 %s",
+	"order at %d and %d have same %s":       "位置 %d 和 %d 的 order 具有相同的 %s",
+	"at %s":                                 "位於 %s",
+
+	// format --
+	"%s contains non-digit":         "%s 包含非數字字元",
+	"%s is empty":                   "%s 為空",
+	"%s starts with zero":           "%s 以零開頭",
+	"11th character must be t or T": "第 11 個字元必須為 t 或 T",
+	"backslash and quote are not allowed within quoted local part": "帶引號的本地部分不允許包含反斜線和引號",
+	"build identifier is empty":                                    "建置識別碼為空",
+	"build is empty":                                               "建置資訊為空",
+	"consecutive dots":                                             "包含連續的點",
+	"contains \\":                                                  "包含 \\",
+	"decimal must be between 0 and 255":                            "十進位數必須介於 0 到 255 之間",
+	"element %d must be %d characters long":                        "第 %d 個元素的長度必須為 %d 個字元",
+	"ends with dot":                                                "以點結尾",
+	"expected four decimals":                                       "應為四個十進位數",
+	"hour/min in offset out of range":                              "偏移量中的時/分超出範圍",
+	"hour/min/sec out of range":                                    "時/分/秒超出範圍",
+	"invalid character %q":                                         "無效字元 %q",
+	"invalid character %q in build identifier":                     "建置識別碼中包含無效字元 %q",
+	"invalid character %q in pre-release identifier":               "預發行識別碼中包含無效字元 %q",
+	"invalid date element: %v":                                     "無效的日期部分：%v",
+	"invalid domain: %v":                                           "無效的網域：%v",
+	"invalid end date-time: %v":                                    "無效的結束日期時間：%v",
+	"invalid end duration: %v":                                     "無效的結束時長：%v",
+	"invalid hour/min in offset":                                   "偏移量中的時/分無效",
+	"invalid hour/min/sec":                                         "時/分/秒無效",
+	"invalid ipv4 address: %v":                                     "無效的 ipv4 位址：%v",
+	"invalid ipv6 address: %v":                                     "無效的 ipv6 位址：%v",
+	"invalid leap second":                                          "無效的閏秒",
+	"invalid start date-time: %v":                                  "無效的開始日期時間：%v",
+	"invalid start duration: %v":                                   "無效的開始時長：%v",
+	"invalid time element: %v":                                     "無效的時間部分：%v",
+	"invalid unit %q":                                              "無效的單位 %q",
+	"invalid week":                                                 "無效的週",
+	"ipv6 address not enclosed in brackets":                        "ipv6 位址未以方括號括住",
+	"label ends with hyphen":                                       "標籤以連字號結尾",
+	"label must be 1 to 63 characters long":                        "標籤長度必須為 1 到 63 個字元",
+	"label starts with hyphen":                                     "標籤以連字號開頭",
+	"leading zeros":                                                "包含前導零",
+	"less than 20 characters long":                                 "長度少於 20 個字元",
+	"less than 9 characters long":                                  "長度少於 9 個字元",
+	"local part more than 64 characters long":                      "本地部分長度超過 64 個字元",
+	"missing @":                                                    "缺少 @",
+	"missing colon":                                                "缺少冒號",
+	"missing colon in correct place":                               "正確位置缺少冒號",
+	"missing colon in offset in correct place":                     "偏移量的正確位置缺少冒號",
+	"missing hour/min/sec":                                         "缺少時/分/秒",
+	"missing number":                                               "缺少數字",
+	"missing slash":                                                "缺少斜線",
+	"missing unit":                                                 "缺少單位",
+	"more than 253 characters long":                                "長度超過 253 個字元",
+	"more than 255 characters long":                                "長度超過 255 個字元",
+	"more than one T":                                              "包含多個 T",
+	"must have %d elements":                                        "必須包含 %d 個部分",
+	"must start with P":                                            "必須以 P 開頭",
+	"must start with non-negative integer":                         "必須以非負整數開頭",
+	"nested curly braces":                                          "巢狀的大括號",
+	"no digits in second fraction":                                 "秒的小數部分沒有數字",
+	"no matching closing brace":                                    "沒有對應的右大括號",
+	"no number in week":                                            "週中沒有數字",
+	"no time elements":                                             "沒有時間部分",
+	"non-hex character %q":                                         "非十六進位字元 %q",
+	"non-positive hour/min in offset":                              "偏移量中的時/分不是正數",
+	"non-positive hour/min/sec":                                    "時/分/秒不是正數",
+	"not starting with /":                                          "不以 / 開頭",
+	"nothing after P":                                              "P 之後沒有內容",
+	"offset must be 6 characters long":                             "偏移量長度必須為 6 個字元",
+	"offset must begin with plus/minus":                            "偏移量必須以加號或減號開頭",
+	"percent decode failed: %v":                                    "百分比解碼失敗：%v",
+	"pre-release identifier is empty":                              "預發行識別碼為空",
+	"pre-release numeric identifier starts with zero":              "預發行數字識別碼以零開頭",
+	"relative url":                                                 "相對 url",
+	"starts with dot":                                              "以點開頭",
+	"starts with zero":                                             "以零開頭",
+	"unit %q out of order":                                         "單位 %q 順序錯誤",
+	"versionCore must have 3 numbers separated by dot":             "versionCore 必須是以點分隔的 3 個數字",
+	"zone id is not a part of ipv6 address":                        "區域 id 不是 ipv6 位址的一部分",
+	"~ must be followed by 0 or 1":                                 "~ 後面必須接 0 或 1",
+}
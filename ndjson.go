@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// LineResult is the result of validating a line
+// of line-delimited json.
+type LineResult struct {
+	// Line is 1-based line number in input.
+	Line int
+
+	// Err is nil if line is valid. Otherwise it is either
+	// *ValidationError, or the error in parsing the line.
+	Err error
+}
+
+// ValidateNDJSON validates each line read from r as independent json
+// document, also known as NDJSON or JSON Lines. Blank lines are skipped.
+//
+// Lines are validated concurrently by given number of workers. If workers
+// is not positive, runtime.GOMAXPROCS(0) workers are used. Results are
+// passed to yield in input order, from the calling goroutine. If yield
+// returns false, validation stops; in that case the goroutine reading r
+// may remain blocked until the pending read on r returns.
+//
+// It returns the error, if any, in reading r.
+func (sch *Schema) ValidateNDJSON(r io.Reader, workers int, yield func(LineResult) bool) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		seq  int
+		line int
+		data []byte
+	}
+	type result struct {
+		seq int
+		LineResult
+	}
+	jobs := make(chan job)
+	results := make(chan result)
+	done := make(chan struct{})
+	inflight := make(chan struct{}, 2*workers) // limits results waiting for reorder
+
+	// reader --
+	var readErr error
+	go func() {
+		defer close(jobs)
+		br := bufio.NewReader(r)
+		seq := 0
+		for line := 1; ; line++ {
+			data, err := br.ReadBytes('\n')
+			if len(bytes.TrimSpace(data)) > 0 {
+				select {
+				case inflight <- struct{}{}:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- job{seq, line, data}:
+					seq++
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	// workers --
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var j job
+				select {
+				case jb, ok := <-jobs:
+					if !ok {
+						return
+					}
+					j = jb
+				case <-done:
+					return
+				}
+				res := result{j.seq, LineResult{j.line, sch.validateLine(j.data)}}
+				select {
+				case results <- res:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// yield in input order --
+	pending := map[int]LineResult{}
+	next := 0
+	for res := range results {
+		pending[res.seq] = res.LineResult
+		for {
+			lr, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-inflight
+			if !yield(lr) {
+				close(done)
+				return nil
+			}
+		}
+	}
+	return readErr
+}
+
+func (sch *Schema) validateLine(data []byte) error {
+	v, err := UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return sch.Validate(v)
+}
@@ -0,0 +1,70 @@
+package jsonschema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestValidateNDJSON(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "object",
+		"properties": { "id": { "type": "integer" } },
+		"required": ["id"]
+	}`)
+
+	var sb strings.Builder
+	var want []string
+	for i := 1; i <= 500; i++ {
+		switch i % 4 {
+		case 0:
+			sb.WriteString(fmt.Sprintf(`{"id": %d}`, i))
+			want = append(want, fmt.Sprintf("%d ok", i))
+		case 1:
+			sb.WriteString(fmt.Sprintf(`{"id": "%d"}`, i))
+			want = append(want, fmt.Sprintf("%d invalid", i))
+		case 2:
+			sb.WriteString(`{"id": `)
+			want = append(want, fmt.Sprintf("%d syntax", i))
+		case 3:
+			sb.WriteString("  ") // blank line
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, workers := range []int{0, 1, 8} {
+		var got []string
+		err := sch.ValidateNDJSON(strings.NewReader(sb.String()), workers, func(r jsonschema.LineResult) bool {
+			switch r.Err.(type) {
+			case nil:
+				got = append(got, fmt.Sprintf("%d ok", r.Line))
+			case *jsonschema.ValidationError:
+				got = append(got, fmt.Sprintf("%d invalid", r.Line))
+			default:
+				got = append(got, fmt.Sprintf("%d syntax", r.Line))
+			}
+			return true
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("workers=%d:\n got: %v\nwant: %v", workers, got, want)
+		}
+	}
+
+	// stop early
+	n := 0
+	err := sch.ValidateNDJSON(strings.NewReader(sb.String()), 4, func(r jsonschema.LineResult) bool {
+		n++
+		return n < 10
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 10 {
+		t.Fatalf("yield called %d times, want 10", n)
+	}
+}
@@ -0,0 +1,86 @@
+package jsonschema
+
+import (
+	"strings"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// NullableVocabURL is the url of vocabulary returned by [NullableVocab].
+const NullableVocabURL = "https://github.com/liuxd6825/jsonschema/vocab/nullable"
+
+// NullableVocab returns the vocabulary that introduces keywords
+// notNull and nullable:
+//
+//   - "notNull": true rejects null, even if "type" includes "null".
+//   - "nullable": true accepts null, even if "type" does not include "null".
+//
+// Both keywords must be boolean, and they cannot be true in the same schema.
+// They have no effect when false.
+//
+// Register it using [Compiler.RegisterVocabulary]. Note that for
+// draft >= 2019-09 vocabularies are disabled, unless enabled via
+// $vocabulary in metaschema or by [Compiler.AssertVocabs].
+func NullableVocab() *Vocabulary {
+	schema, err := UnmarshalJSON(strings.NewReader(`{
+		"properties": {
+			"notNull": { "type": "boolean" },
+			"nullable": { "type": "boolean" }
+		},
+		"if": {
+			"properties": { "notNull": { "const": true } },
+			"required": ["notNull"]
+		},
+		"then": {
+			"properties": { "nullable": { "const": false } }
+		}
+	}`))
+	if err != nil {
+		panic(err)
+	}
+
+	c := NewCompiler()
+	if err := c.AddResource(NullableVocabURL, schema); err != nil {
+		panic(err)
+	}
+
+	return &Vocabulary{
+		URL:     NullableVocabURL,
+		Schema:  c.MustCompile(NullableVocabURL),
+		Compile: compileNullability,
+	}
+}
+
+func compileNullability(ctx *CompilerContext, obj map[string]any) (SchemaExt, error) {
+	notNull, _ := obj["notNull"].(bool)
+	nullable, _ := obj["nullable"].(bool)
+	if !notNull && !nullable {
+		return nil, nil
+	}
+	return &nullability{notNull, nullable}, nil
+}
+
+type nullability struct {
+	notNull  bool
+	nullable bool // applied to Schema.Types during compilation
+}
+
+// adjust updates the type of s to allow null if nullable.
+func (n *nullability) adjust(s *Schema) {
+	if n.nullable && s.Types != nil {
+		s.Types.add(nullType)
+	}
+}
+
+func (n *nullability) marshalKeywords() map[string]any {
+	if n.notNull {
+		return map[string]any{"notNull": true}
+	}
+	return map[string]any{"nullable": true}
+}
+
+func (n *nullability) Validate(ctx *ValidatorContext, v any) {
+	if n.notNull && v == nil {
+		ctx.AddError(&kind.NotNull{})
+	}
+}
@@ -0,0 +1,72 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+func compileNullable(t *testing.T, schema string) (*jsonschema.Schema, error) {
+	t.Helper()
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertVocabs()
+	c.RegisterVocabulary(jsonschema.NullableVocab())
+	if err := c.AddResource("schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	return c.Compile("schema.json")
+}
+
+func TestNullableVocab(t *testing.T) {
+	tests := []struct {
+		schema string
+		v      any
+		valid  bool
+	}{
+		{`{"notNull": true}`, nil, false},
+		{`{"notNull": true}`, "a", true},
+		{`{"notNull": false}`, nil, true},
+		{`{"type": ["string", "null"], "notNull": true}`, nil, false},
+		{`{"type": ["string", "null"], "notNull": true}`, "a", true},
+		{`{"type": "string", "nullable": true}`, nil, true},
+		{`{"type": "string", "nullable": true}`, "a", true},
+		{`{"type": "string", "nullable": true}`, 1, false},
+		{`{"type": "string", "nullable": false}`, nil, false},
+		{`{"properties": {"a": {"type": "date", "notNull": true}}}`, map[string]any{"a": nil}, false},
+		{`{"properties": {"a": {"type": "date", "notNull": true}}}`, map[string]any{}, true},
+	}
+	for _, test := range tests {
+		sch, err := compileNullable(t, test.schema)
+		if err != nil {
+			t.Fatalf("%s: %v", test.schema, err)
+		}
+		if got := sch.Validate(test.v) == nil; got != test.valid {
+			t.Errorf("%s with %v: got %v, want %v", test.schema, test.v, got, test.valid)
+		}
+	}
+
+	sch, err := compileNullable(t, `{"notNull": true}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verr := sch.Validate(nil).(*jsonschema.ValidationError)
+	if _, ok := verr.Causes[0].ErrorKind.(*kind.NotNull); !ok {
+		t.Errorf("got %#v, want *kind.NotNull", verr.Causes[0].ErrorKind)
+	}
+	if got := verr.Causes[0].Error(); !strings.Contains(got, "null is not allowed") {
+		t.Errorf("got %q", got)
+	}
+
+	// invalid keywords
+	for _, schema := range []string{`{"notNull": 1}`, `{"notNull": true, "nullable": true}`} {
+		if _, err := compileNullable(t, schema); err == nil {
+			t.Errorf("%s: compilation must fail", schema)
+		}
+	}
+}
@@ -76,6 +76,9 @@ func (c *objCompiler) compile(s *Schema) error {
 			return err
 		}
 		if ext != nil {
+			if adjuster, ok := ext.(schemaAdjuster); ok {
+				adjuster.adjust(s)
+			}
 			s.Extensions = append(s.Extensions, ext)
 		}
 	}
@@ -144,7 +147,6 @@ func (c *objCompiler) compileDraft4(s *Schema) error {
 			s.Types = newTypes(t)
 		}
 		if arr := c.arrVal("enum"); arr != nil {
-			arr = append(arr, "date", "datetime")
 			s.Enum = newEnum(arr)
 		}
 		s.MultipleOf = c.numVal("multipleOf")
@@ -163,6 +165,19 @@ func (c *objCompiler) compileDraft4(s *Schema) error {
 			s.ExclusiveMinimum = c.numVal("exclusiveMinimum")
 		}
 
+		if s.DateMinimum, err = c.dateBound("dateMinimum"); err != nil {
+			return err
+		}
+		if s.DateMaximum, err = c.dateBound("dateMaximum"); err != nil {
+			return err
+		}
+		if s.DateExclusiveMinimum, err = c.dateBound("dateExclusiveMinimum"); err != nil {
+			return err
+		}
+		if s.DateExclusiveMaximum, err = c.dateBound("dateExclusiveMaximum"); err != nil {
+			return err
+		}
+
 		s.MinLength = c.intVal("minLength")
 		s.MaxLength = c.intVal("maxLength")
 		if pat := c.strVal("pattern"); pat != nil {
@@ -184,6 +199,7 @@ func (c *objCompiler) compileDraft4(s *Schema) error {
 	}
 
 	// format --
+	s.formatName = c.string("format")
 	if c.assertFormat(s.DraftVersion) {
 		if f := c.strVal("format"); f != nil {
 			if *f == "regex" {
@@ -242,6 +258,8 @@ func (c *objCompiler) compileDraft7(s *Schema) error {
 		}
 	}
 
+	s.contentEncodingName = c.string("contentEncoding")
+	s.contentMediaTypeName = c.string("contentMediaType")
 	if c.c.assertContent {
 		if ce := c.strVal("contentEncoding"); ce != nil {
 			s.ContentEncoding = c.c.decoders[*ce]
@@ -3,7 +3,11 @@ package jsonschema
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/liuxd6825/jsonschema/v6/kind"
 	"golang.org/x/text/language"
@@ -11,7 +15,6 @@ import (
 )
 
 var defaultPrinter = message.NewPrinter(language.English)
-var chinesePrinter = message.NewPrinter(language.Chinese)
 
 // format ---
 
@@ -62,6 +65,9 @@ func (e *ValidationError) display(sb *strings.Builder, verbose bool, indent int,
 			sb.WriteString(e.ErrorKind.LocalizedString(p))
 		} else {
 			sb.WriteString(p.Sprintf("at %s", quote(jsonPtr(e.InstanceLocation))))
+			if e.InstancePosition != nil {
+				fmt.Fprintf(sb, " (%s)", e.InstancePosition)
+			}
 			if verbose {
 				schLoc := absKwLoc
 				if prevAbsKwLoc != "" {
@@ -71,9 +77,14 @@ func (e *ValidationError) display(sb *strings.Builder, verbose bool, indent int,
 						schLoc = fmt.Sprintf("S#%s", f)
 					}
 				}
+				if e.KeywordPosition != nil {
+					schLoc = fmt.Sprintf("%s %s", schLoc, e.KeywordPosition)
+				}
 				fmt.Fprintf(sb, " [%s]", schLoc)
+			} else if e.KeywordPosition != nil {
+				fmt.Fprintf(sb, " [%s]", e.KeywordPosition)
 			}
-			fmt.Fprintf(sb, ": %s", e.ErrorKind.LocalizedString(p))
+			fmt.Fprintf(sb, ": %s", e.message(p))
 		}
 	}
 	for _, cause := range e.Causes {
@@ -81,11 +92,25 @@ func (e *ValidationError) display(sb *strings.Builder, verbose bool, indent int,
 	}
 }
 
+// message returns the custom message if set,
+// otherwise the message of ErrorKind.
+func (e *ValidationError) message(p *message.Printer) string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.ErrorKind.LocalizedString(p)
+}
+
 func (e *ValidationError) Error() string {
 	return e.LocalizedError(defaultPrinter)
 }
 
+// LocalizedError returns the error message in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter] with
+// the default catalog; in either case the translations of this
+// package, including those added by [RegisterMessages], are used.
 func (e *ValidationError) LocalizedError(p *message.Printer) string {
+	p = localize(p)
 	var sb strings.Builder
 	e.display(&sb, false, 0, "", p)
 	return sb.String()
@@ -95,7 +120,11 @@ func (e *ValidationError) GoString() string {
 	return e.LocalizedGoString(defaultPrinter)
 }
 
+// LocalizedGoString is like [ValidationError.GoString], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
 func (e *ValidationError) LocalizedGoString(p *message.Printer) string {
+	p = localize(p)
 	var sb strings.Builder
 	e.display(&sb, true, 0, "", p)
 	return sb.String()
@@ -129,16 +158,25 @@ type OutputUnit struct {
 	KeywordLocation         string       `json:"keywordLocation"`
 	AbsoluteKeywordLocation string       `json:"AbsoluteKeywordLocation,omitempty"`
 	InstanceLocation        string       `json:"instanceLocation"`
+	KeywordPosition         string       `json:"keywordPosition,omitempty"`
+	InstancePosition        string       `json:"instancePosition,omitempty"`
 	Error                   *OutputError `json:"error,omitempty"`
+	ErrorInfo               *ErrorInfo   `json:"errorInfo,omitempty"`
 	Errors                  []OutputUnit `json:"errors,omitempty"`
+	Annotation              any          `json:"annotation,omitempty"`
+	Annotations             []OutputUnit `json:"annotations,omitempty"`
 }
 
 type OutputError struct {
-	Kind ErrorKind
-	p    *message.Printer
+	Kind    ErrorKind
+	Message string // custom message, overrides message of Kind
+	p       *message.Printer
 }
 
 func (k OutputError) MarshalJSON() ([]byte, error) {
+	if k.Message != "" {
+		return json.Marshal(k.Message)
+	}
 	return json.Marshal(k.Kind.LocalizedString(k.p))
 }
 
@@ -147,7 +185,11 @@ func (e *ValidationError) BasicOutput() *OutputUnit {
 	return e.LocalizedBasicOutput(defaultPrinter)
 }
 
+// LocalizedBasicOutput is like [ValidationError.BasicOutput], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
 func (e *ValidationError) LocalizedBasicOutput(p *message.Printer) *OutputUnit {
+	p = localize(p)
 	out := e.output(true, false, "", "", p)
 	return &out
 }
@@ -157,7 +199,11 @@ func (e *ValidationError) DetailedOutput() *OutputUnit {
 	return e.LocalizedDetailedOutput(defaultPrinter)
 }
 
+// LocalizedDetailedOutput is like [ValidationError.DetailedOutput], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
 func (e *ValidationError) LocalizedDetailedOutput(p *message.Printer) *OutputUnit {
+	p = localize(p)
 	out := e.output(false, false, "", "", p)
 	return &out
 }
@@ -189,6 +235,12 @@ func (e *ValidationError) output(flatten, inRef bool, schemaURL, kwLoc string, p
 	if inRef {
 		out.AbsoluteKeywordLocation = e.absoluteKeywordLocation()
 	}
+	if e.KeywordPosition != nil {
+		out.KeywordPosition = e.KeywordPosition.String()
+	}
+	if e.InstancePosition != nil {
+		out.InstancePosition = e.InstancePosition.String()
+	}
 	for _, cause := range e.Causes {
 		causeOut := cause.output(flatten, inRef, schemaURL, kwLoc, p)
 		if cause.skip() {
@@ -197,7 +249,8 @@ func (e *ValidationError) output(flatten, inRef bool, schemaURL, kwLoc string, p
 		if flatten {
 			errors := causeOut.Errors
 			causeOut.Errors = nil
-			causeOut.Error = &OutputError{cause.ErrorKind, p}
+			causeOut.Error = &OutputError{cause.ErrorKind, cause.Message, p}
+			causeOut.ErrorInfo = NewErrorInfo(cause.ErrorKind)
 			out.Errors = append(out.Errors, causeOut)
 			if len(errors) > 0 {
 				out.Errors = append(out.Errors, errors...)
@@ -207,7 +260,257 @@ func (e *ValidationError) output(flatten, inRef bool, schemaURL, kwLoc string, p
 		}
 	}
 	if len(out.Errors) == 0 {
-		out.Error = &OutputError{e.ErrorKind, p}
+		out.Error = &OutputError{e.ErrorKind, e.Message, p}
+		out.ErrorInfo = NewErrorInfo(e.ErrorKind)
 	}
 	return out
 }
+
+// --
+
+// FieldErrors flattens the errors into messages keyed by instance
+// location, ex: {"/data/name": ["minLength: got 3, want 10"]}.
+// Suitable for form and api responses.
+//
+// Wrappers such as [kind.Group], [kind.Reference], [kind.Schema] and
+// [kind.AllOf] are collapsed into their causes. For failed anyOf and
+// oneOf, only the errors of the branch that matched closest are
+// reported. Branches are ranked by:
+//
+//   - type and const matched: the branch has no type error at the
+//     location of anyOf/oneOf, and no const or enum error at that
+//     location or its immediate children, such as a discriminator
+//     property.
+//   - deepest instance location of errors.
+//   - fewest errors.
+//
+// If every branch failed type check, a single type error is reported
+// with all wanted types.
+func (e *ValidationError) FieldErrors() map[string][]string {
+	return e.LocalizedFieldErrors(defaultPrinter)
+}
+
+// LocalizedFieldErrors is like [ValidationError.FieldErrors], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
+func (e *ValidationError) LocalizedFieldErrors(p *message.Printer) map[string][]string {
+	p = localize(p)
+	m := map[string][]string{}
+	for _, leaf := range e.leafErrors() {
+		loc := jsonPtr(leaf.InstanceLocation)
+		if msg := leaf.message(p); !slices.Contains(m[loc], msg) {
+			m[loc] = append(m[loc], msg)
+		}
+	}
+	return m
+}
+
+// BestMatch returns the single error most relevant to the user.
+// Among the errors reported by [ValidationError.FieldErrors], the one
+// at deepest instance location is returned. If there are many, the
+// first one is returned.
+func (e *ValidationError) BestMatch() *ValidationError {
+	leaves := e.leafErrors()
+	best := leaves[0]
+	for _, leaf := range leaves[1:] {
+		if len(leaf.InstanceLocation) > len(best.InstanceLocation) {
+			best = leaf
+		}
+	}
+	return best
+}
+
+// leafErrors returns the errors that are meaningful to end user.
+func (e *ValidationError) leafErrors() []*ValidationError {
+	if e.Message != "" || len(e.Causes) == 0 {
+		return []*ValidationError{e}
+	}
+	switch e.ErrorKind.(type) {
+	case *kind.Group, *kind.Schema, *kind.Reference, *kind.AllOf:
+		var leaves []*ValidationError
+		for _, cause := range e.Causes {
+			leaves = append(leaves, cause.leafErrors()...)
+		}
+		return leaves
+	case *kind.AnyOf, *kind.OneOf:
+		return e.alternativeErrors()
+	}
+	return []*ValidationError{e}
+}
+
+// alternativeErrors returns the leaf errors of the branch of
+// failed anyOf or oneOf, that matched closest.
+func (e *ValidationError) alternativeErrors() []*ValidationError {
+	var best []*ValidationError
+	var bestMatched bool
+	var bestDepth int
+	var typ *kind.Type // merged type errors, nil if some branch has none
+	for i, branch := range e.Causes {
+		leaves := branch.leafErrors()
+		if t := e.typeError(leaves); t != nil && (i == 0 || typ != nil) {
+			if typ == nil {
+				typ = &kind.Type{Got: t.Got}
+			}
+			for _, want := range t.Want {
+				if !slices.Contains(typ.Want, want) {
+					typ.Want = append(typ.Want, want)
+				}
+			}
+		} else {
+			typ = nil
+		}
+
+		matched, depth := !e.mismatched(leaves), maxDepth(leaves)
+		switch {
+		case best == nil:
+		case matched != bestMatched:
+			if !matched {
+				continue
+			}
+		case depth != bestDepth:
+			if depth < bestDepth {
+				continue
+			}
+		case len(leaves) >= len(best):
+			continue
+		}
+		best, bestMatched, bestDepth = leaves, matched, depth
+	}
+	if typ != nil {
+		return []*ValidationError{{
+			SchemaURL:        e.SchemaURL,
+			InstanceLocation: e.InstanceLocation,
+			ErrorKind:        typ,
+		}}
+	}
+	if best == nil {
+		return []*ValidationError{e}
+	}
+	return best
+}
+
+// typeError returns the type error among leaves,
+// that is reported at the location of e.
+func (e *ValidationError) typeError(leaves []*ValidationError) *kind.Type {
+	for _, leaf := range leaves {
+		if t, ok := leaf.ErrorKind.(*kind.Type); ok && slices.Equal(leaf.InstanceLocation, e.InstanceLocation) {
+			return t
+		}
+	}
+	return nil
+}
+
+// mismatched tells whether leaves of a branch of e, show that
+// the branch is not meant for the instance.
+func (e *ValidationError) mismatched(leaves []*ValidationError) bool {
+	for _, leaf := range leaves {
+		rel := len(leaf.InstanceLocation) - len(e.InstanceLocation)
+		switch leaf.ErrorKind.(type) {
+		case *kind.Type:
+			if rel == 0 {
+				return true
+			}
+		case *kind.Const, *kind.Enum:
+			if rel <= 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func maxDepth(errors []*ValidationError) int {
+	depth := 0
+	for _, err := range errors {
+		depth = max(depth, len(err.InstanceLocation))
+	}
+	return depth
+}
+
+// --
+
+// ErrorInfo is the machine-readable form of [ErrorKind].
+type ErrorInfo struct {
+	// Keyword that failed. empty for kinds such as
+	// [kind.Group] that are not specific to a keyword.
+	Keyword string `json:"keyword,omitempty"`
+
+	// Code identifies the kind of error. It is kebab-case of
+	// the kind's type name, ex: "min-length" for [kind.MinLength].
+	Code string `json:"code"`
+
+	// Params holds the fields of the kind, ex: got and want.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// ErrorParams can be implemented by [ErrorKind] to provide
+// its params in [ErrorInfo]. Otherwise params are collected
+// from exported fields of the kind.
+type ErrorParams interface {
+	ErrorParams() map[string]any
+}
+
+// NewErrorInfo returns the machine-readable form of k.
+func NewErrorInfo(k ErrorKind) *ErrorInfo {
+	info := &ErrorInfo{}
+	if path := k.KeywordPath(); len(path) > 0 {
+		info.Keyword = path[0]
+	}
+	rv := reflect.ValueOf(k)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	info.Code = kebabCase(rv.Type().Name())
+
+	if ep, ok := k.(ErrorParams); ok {
+		info.Params = ep.ErrorParams()
+	} else if rv.Kind() == reflect.Struct {
+		for i := 0; i < rv.NumField(); i++ {
+			f := rv.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if info.Params == nil {
+				info.Params = map[string]any{}
+			}
+			name := []rune(f.Name)
+			name[0] = unicode.ToLower(name[0])
+			info.Params[string(name)] = errorParam(rv.Field(i).Interface())
+		}
+	}
+	return info
+}
+
+// errorParam converts v into value that marshals
+// into json as expected.
+func errorParam(v any) any {
+	switch v := v.(type) {
+	case *big.Rat:
+		if v == nil {
+			return nil
+		}
+		if v.IsInt() {
+			return json.Number(v.Num().String())
+		}
+		f, _ := v.Float64()
+		return f
+	case error:
+		return v.Error()
+	}
+	return v
+}
+
+func kebabCase(s string) string {
+	var sb strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
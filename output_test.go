@@ -143,3 +143,145 @@ func TestOutputSuites(t *testing.T) {
 	testOuputSuite(t, "./testdata/JSON-Schema-Test-Suite")
 	testOuputSuite(t, "./testdata/Extra-Test-Suite")
 }
+
+func TestErrorInfo(t *testing.T) {
+	sch := compileString(t, `{
+		"properties": {
+			"name": { "type": "string", "minLength": 3 },
+			"age": { "maximum": 150.5 }
+		},
+		"required": ["id"]
+	}`)
+	err := sch.Validate(unmarshalString(t, `{"name": "ab", "age": 200}`))
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		t.Fatalf("got %v, want *ValidationError", err)
+	}
+	b, err := json.Marshal(verr.BasicOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Errors []struct {
+			InstanceLocation string
+			ErrorInfo        *jsonschema.ErrorInfo
+		}
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, e := range out.Errors {
+		if e.ErrorInfo != nil {
+			params, _ := json.Marshal(e.ErrorInfo.Params)
+			got[e.InstanceLocation] = fmt.Sprintf("%s %s %s", e.ErrorInfo.Keyword, e.ErrorInfo.Code, params)
+		}
+	}
+	want := map[string]string{
+		"":      `required required {"missing":["id"]}`,
+		"/name": `minLength min-length {"got":2,"want":3}`,
+		"/age":  `maximum maximum {"got":200,"want":150.5}`,
+	}
+	for loc, w := range want {
+		if got[loc] != w {
+			t.Errorf("%q: got %s, want %s", loc, got[loc], w)
+		}
+	}
+
+	// custom params
+	info := jsonschema.NewErrorInfo(&jsonschema.OrderKeys{Key: "id", Duplicates: []int{0, 2}})
+	if info.Code != "order-keys" || info.Params["key"] != "id" {
+		t.Errorf("got %+v", info)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	sch := compileString(t, `{
+		"properties": {
+			"data": { "$ref": "#/$defs/data" }
+		},
+		"$defs": {
+			"data": {
+				"properties": {
+					"name": { "type": "string", "minLength": 10, "pattern": "^[a-z]+$" },
+					"id": { "anyOf": [{ "type": "string" }, { "type": "integer" }] },
+					"contact": {
+						"oneOf": [
+							{ "type": "string", "format": "email" },
+							{ "type": "object", "required": ["phone"] }
+						]
+					}
+				},
+				"allOf": [{ "required": ["name"] }, { "required": ["kind"] }]
+			}
+		}
+	}`)
+	inst := unmarshalString(t, `{"data": {"name": "AB", "id": true, "contact": {}}}`)
+	verr, ok := sch.Validate(inst).(*jsonschema.ValidationError)
+	if !ok {
+		t.Fatal("instance must be invalid")
+	}
+	got := verr.FieldErrors()
+	want := map[string][]string{
+		"/data":         {"missing property 'kind'"},
+		"/data/name":    {"minLength: got 2, want 10", "'AB' does not match pattern '^[a-z]+$'"},
+		"/data/id":      {"got boolean, want string or integer"},
+		"/data/contact": {"missing property 'phone'"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for loc, msgs := range want {
+		if strings.Join(got[loc], "\n") != strings.Join(msgs, "\n") {
+			t.Errorf("%q: got %q, want %q", loc, got[loc], msgs)
+		}
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	sch := compileString(t, `{
+		"oneOf": [
+			{
+				"properties": {
+					"kind": { "const": "circle" },
+					"radius": { "type": "number" }
+				},
+				"required": ["kind", "radius"]
+			},
+			{
+				"properties": {
+					"kind": { "const": "rect" },
+					"size": {
+						"properties": { "width": { "type": "number", "minimum": 0 } }
+					}
+				},
+				"required": ["kind", "size"]
+			},
+			{ "type": "string" }
+		]
+	}`)
+	tests := []struct {
+		inst string
+		loc  string
+		msg  string
+	}{
+		{`{"kind": "rect", "size": {"width": -1}}`, "/size/width", "minimum: got -1, want 0"},
+		{`{"kind": "circle", "radius": "big"}`, "/radius", "got string, want number"},
+		{`{"kind": "circle"}`, "", "missing property 'radius'"},
+		{`true`, "", "oneOf failed, subschemas 0, 1 matched"},
+	}
+	for _, test := range tests {
+		verr, ok := sch.Validate(unmarshalString(t, test.inst)).(*jsonschema.ValidationError)
+		if !ok {
+			t.Fatalf("%s: must be invalid", test.inst)
+		}
+		best := verr.BestMatch()
+		loc := "/" + strings.Join(best.InstanceLocation, "/")
+		if len(best.InstanceLocation) == 0 {
+			loc = ""
+		}
+		if loc != test.loc || !strings.Contains(best.Error(), test.msg) {
+			t.Errorf("%s: got %q at %q, want %q at %q", test.inst, best.Error(), loc, test.msg, test.loc)
+		}
+	}
+}
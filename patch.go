@@ -0,0 +1,216 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// PatchOperation is an operation of JSON Patch, RFC 6902.
+type PatchOperation struct {
+	// Op is one of "add", "remove" or "replace".
+	Op string `json:"op"`
+
+	// Path is json-pointer to the target location.
+	Path string `json:"path"`
+
+	// Value to add or replace with. Not used by "remove".
+	Value any `json:"value"`
+}
+
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(map[string]any{"op": op.Op, "path": op.Path})
+	}
+	type operation PatchOperation // avoid recursion
+	return json.Marshal(operation(op))
+}
+
+// SuggestPatch returns JSON Patch, that fixes common validation
+// failures in instance, which is the value validated. The patch
+// is meant to be reviewed before applying. Following errors are
+// fixed:
+//
+//   - [kind.Required]: add missing property, if its schema has default.
+//   - [kind.AdditionalProperties]: remove the properties.
+//   - [kind.Enum]: replace string, that differs from exactly one
+//     allowed value only in case or by a typo of at most two characters.
+//   - [kind.Type]: replace value with lossless conversion, such as
+//     "12" to 12, "true" to true, 12 to "12", or x to [x].
+//   - [kind.MaxLength]: truncate the string.
+//
+// Errors are picked as in [ValidationError.FieldErrors]. Operations
+// are sorted by path, and operations on locations inside a removed
+// or replaced value are dropped.
+func (e *ValidationError) SuggestPatch(instance any) []PatchOperation {
+	var ops []PatchOperation
+	for _, leaf := range e.leafErrors() {
+		ops = append(ops, leaf.suggestPatch(instance)...)
+	}
+	slices.SortStableFunc(ops, func(a, b PatchOperation) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	var result []PatchOperation
+	for _, op := range ops {
+		conflict := slices.ContainsFunc(result, func(prev PatchOperation) bool {
+			return prev.Path == op.Path || (prev.Op != "add" && strings.HasPrefix(op.Path, prev.Path+"/"))
+		})
+		if !conflict {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+func (e *ValidationError) suggestPatch(instance any) []PatchOperation {
+	loc := jsonPtr(e.InstanceLocation)
+	v, ok := valueAt(instance, e.InstanceLocation)
+	if !ok {
+		return nil
+	}
+	var ops []PatchOperation
+	switch k := e.ErrorKind.(type) {
+	case *kind.Required:
+		if e.sch == nil {
+			break
+		}
+		for _, pname := range k.Missing {
+			if psch, ok := e.sch.Properties[pname]; ok {
+				if def := psch.defaultValue(); def != nil {
+					ops = append(ops, PatchOperation{"add", loc + "/" + escape(pname), *def})
+				}
+			}
+		}
+	case *kind.AdditionalProperties:
+		for _, pname := range k.Properties {
+			ops = append(ops, PatchOperation{"remove", loc + "/" + escape(pname), nil})
+		}
+	case *kind.Enum:
+		if s, ok := v.(string); ok {
+			if want, ok := closestString(s, k.Want); ok {
+				ops = append(ops, PatchOperation{"replace", loc, want})
+			}
+		}
+	case *kind.Type:
+		var types Types
+		for _, t := range k.Want {
+			types.add(typeFromString(t))
+		}
+		if cv, ok := losslessConvert(v, types); ok {
+			ops = append(ops, PatchOperation{"replace", loc, cv})
+		}
+	case *kind.MaxLength:
+		if s, ok := v.(string); ok && utf8.RuneCountInString(s) > k.Want {
+			ops = append(ops, PatchOperation{"replace", loc, string([]rune(s)[:k.Want])})
+		}
+	}
+	return ops
+}
+
+// valueAt returns the value in v at location loc.
+func valueAt(v any, loc []string) (any, bool) {
+	for _, tok := range loc {
+		switch vv := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = vv[tok]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(vv) {
+				return nil, false
+			}
+			v = vv[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// closestString returns the only string in values, that equals s
+// ignoring case, or else is at most two edits away from s.
+func closestString(s string, values []any) (string, bool) {
+	var fold, near []string
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok || str == s {
+			continue
+		}
+		if strings.EqualFold(str, s) {
+			fold = append(fold, str)
+		} else if editDistance(str, s) <= 2 {
+			near = append(near, str)
+		}
+	}
+	switch {
+	case len(fold) == 1:
+		return fold[0], true
+	case len(fold) == 0 && len(near) == 1 && utf8.RuneCountInString(s) > 2:
+		return near[0], true
+	}
+	return "", false
+}
+
+// editDistance returns the levenshtein distance between s1 and s2.
+func editDistance(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+	prev := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		cur := make([]int, len(r2)+1)
+		cur[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(r2)]
+}
+
+// losslessConvert converts v to one of types, such that
+// converting back gives v.
+func losslessConvert(v any, types Types) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		if (types.contains(numberType) || types.contains(integerType)) && isJSONNumber(v) {
+			if num := json.Number(v); types.contains(numberType) || isInteger(num) {
+				return num, true
+			}
+		}
+		if types.contains(booleanType) && (v == "true" || v == "false") {
+			return v == "true", true
+		}
+		if types.contains(nullType) && v == "null" {
+			return nil, true
+		}
+	case bool:
+		if types.contains(stringType) {
+			return strconv.FormatBool(v), true
+		}
+	case nil:
+	case map[string]any, []any:
+		return nil, false
+	default:
+		if typeOf(v) == numberType && types.contains(stringType) {
+			return fmt.Sprint(v), true
+		}
+	}
+	if types.contains(arrayType) {
+		return []any{v}, true
+	}
+	return nil, false
+}
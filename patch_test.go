@@ -0,0 +1,95 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestSuggestPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		instance string
+		want     string
+	}{
+		{
+			name:     "required with default",
+			schema:   `{"properties": {"port": {"default": 80}, "host": {}}, "required": ["port", "host"]}`,
+			instance: `{}`,
+			want:     `[{"op":"add","path":"/port","value":80}]`,
+		},
+		{
+			name:     "required with default in $ref",
+			schema:   `{"properties": {"port": {"$ref": "#/$defs/port"}}, "required": ["port"], "$defs": {"port": {"default": 80}}}`,
+			instance: `{}`,
+			want:     `[{"op":"add","path":"/port","value":80}]`,
+		},
+		{
+			name:     "additionalProperties",
+			schema:   `{"properties": {"a": {}}, "additionalProperties": false}`,
+			instance: `{"a": 1, "b~c": 2}`,
+			want:     `[{"op":"remove","path":"/b~0c"}]`,
+		},
+		{
+			name:     "enum case",
+			schema:   `{"properties": {"color": {"enum": ["Red", "Green"]}}}`,
+			instance: `{"color": "red"}`,
+			want:     `[{"op":"replace","path":"/color","value":"Red"}]`,
+		},
+		{
+			name:     "enum typo",
+			schema:   `{"enum": ["debug", "info", "warning"]}`,
+			instance: `"warnign"`,
+			want:     `[{"op":"replace","path":"","value":"warning"}]`,
+		},
+		{
+			name:     "enum ambiguous",
+			schema:   `{"enum": ["cat", "car"]}`,
+			instance: `"cab"`,
+			want:     `null`,
+		},
+		{
+			name:     "type",
+			schema:   `{"items": {"type": "integer"}, "properties": {"on": {"type": "boolean"}, "id": {"type": "string"}, "tags": {"type": "array"}}}`,
+			instance: `{"on": "true", "id": 12, "tags": "x"}`,
+			want:     `[{"op":"replace","path":"/id","value":"12"},{"op":"replace","path":"/on","value":true},{"op":"replace","path":"/tags","value":["x"]}]`,
+		},
+		{
+			name:     "type lossy",
+			schema:   `{"items": {"type": "integer"}}`,
+			instance: `["1.5", "2"]`,
+			want:     `[{"op":"replace","path":"/1","value":2}]`,
+		},
+		{
+			name:     "maxLength",
+			schema:   `{"maxLength": 3}`,
+			instance: `"héllo"`,
+			want:     `[{"op":"replace","path":"","value":"hél"}]`,
+		},
+		{
+			name:     "multiple",
+			schema:   `{"properties": {"a": {}, "b": {"maxLength": 1}}, "additionalProperties": false}`,
+			instance: `{"a": 1, "b": "xyz", "c": {"d": 1}}`,
+			want:     `[{"op":"replace","path":"/b","value":"x"},{"op":"remove","path":"/c"}]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sch := compileString(t, test.schema)
+			inst := unmarshalString(t, test.instance)
+			verr, ok := sch.Validate(inst).(*jsonschema.ValidationError)
+			if !ok {
+				t.Fatal("instance must be invalid")
+			}
+			got, err := json.Marshal(verr.SuggestPatch(inst))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Reflect returns the json schema document describing values of
+// go type t, as encoded by [json.Marshal]. The document uses draft 2020-12.
+//
+// The type mapping is:
+//
+//   - bool is "boolean", integer kinds are "integer", float kinds
+//     and [json.Number] are "number", string kinds are "string".
+//   - [time.Time] is "datetime".
+//   - []byte is "string" with contentEncoding "base64".
+//   - slices and arrays are "array"; arrays also fix the length.
+//   - maps are "object" with additionalProperties.
+//   - structs are "object" with fields as properties, chosen as
+//     [json.Marshal] does. json tags are honoured, and fields without
+//     omitempty are required, unless promoted through embedded pointer.
+//     Embedded structs, that are not named in json tag, are added to
+//     allOf. If some of their fields are shadowed by other fields, or
+//     they are embedded through pointer, allOf gets a schema with only
+//     the fields promoted from them, instead of their schema.
+//   - pointers are nullable.
+//   - interfaces and [json.Marshaler] implementations accept any value.
+//     [encoding.TextMarshaler] implementations are "string".
+//   - types implementing [ISchemaType] use the type they return.
+//
+// Types that refer to themselves are put in $defs and referred with $ref.
+// Channels, functions and complex numbers are not supported.
+//
+// The struct tag "jsonschema" adds keywords to schema of the field. It is
+// comma separated list of key=value pairs. Commas in value are escaped with
+// backslash. ex:
+//
+//	Name string `jsonschema:"minLength=3,order=2,title=Full Name"`
+//
+// Supported keys are:
+//
+//   - numbers: minLength, maxLength, minItems, maxItems, minProperties,
+//     maxProperties, minContains, maxContains, minimum, maximum,
+//     exclusiveMinimum, exclusiveMaximum, multipleOf, order
+//   - strings: title, description, pattern, format, name, $comment,
+//     contentEncoding, contentMediaType
+//   - booleans: uniqueItems, readOnly, writeOnly, deprecated. value
+//     defaults to true.
+//   - values: default, const and enum, whose values are separated by "|".
+//     value is parsed as json if valid, else taken as string.
+//   - flags: required marks the field required even with omitempty,
+//     nullable allows null.
+func Reflect(t reflect.Type) (map[string]any, error) {
+	r := &reflector{
+		root:     t,
+		visiting: map[reflect.Type]bool{},
+		defNames: map[reflect.Type]string{},
+		defs:     map[string]any{},
+	}
+	sch, err := r.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]any{"$schema": Draft2020.url}
+	for k, v := range sch {
+		doc[k] = v
+	}
+	if len(r.defs) > 0 {
+		doc["$defs"] = r.defs
+	}
+	return doc, nil
+}
+
+// CompileType compiles the schema document returned by [Reflect]
+// for go type t. The document is added as resource with url loc.
+func (c *Compiler) CompileType(loc string, t reflect.Type) (*Schema, error) {
+	doc, err := Reflect(t)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.AddResource(loc, doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(loc)
+}
+
+type reflector struct {
+	root     reflect.Type
+	visiting map[reflect.Type]bool
+	recurses map[reflect.Type]bool
+	defNames map[reflect.Type]string
+	defs     map[string]any
+}
+
+func (r *reflector) schema(t reflect.Type) (map[string]any, error) {
+	if t == nil {
+		return map[string]any{}, nil
+	}
+	if t.Kind() == reflect.Pointer && !t.Implements(schemaTypeType) {
+		sch, err := r.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(sch), nil
+	}
+
+	switch {
+	case t.Kind() == reflect.Interface:
+		return map[string]any{}, nil
+	case t == timeType:
+		return map[string]any{"type": "datetime"}, nil
+	case t == jsonNumberType:
+		return map[string]any{"type": "number"}, nil
+	case t.Implements(schemaTypeType):
+		st := reflect.New(t).Elem().Interface().(ISchemaType)
+		return map[string]any{"type": st.GetSchemaType()}, nil
+	case reflect.PointerTo(t).Implements(schemaTypeType):
+		st := reflect.New(t).Interface().(ISchemaType)
+		return map[string]any{"type": st.GetSchemaType()}, nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{}, nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		return r.composite(t)
+	}
+	return nil, &UnsupportedValueError{Type: t.String()}
+}
+
+// composite returns schema of t, which may refer itself.
+func (r *reflector) composite(t reflect.Type) (map[string]any, error) {
+	if name, ok := r.defNames[t]; ok {
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+	if r.visiting[t] {
+		if t == r.root {
+			return map[string]any{"$ref": "#"}, nil
+		}
+		if r.recurses == nil {
+			r.recurses = map[reflect.Type]bool{}
+		}
+		r.recurses[t] = true
+		return map[string]any{"$ref": "#/$defs/" + r.defName(t)}, nil
+	}
+
+	r.visiting[t] = true
+	var sch map[string]any
+	var err error
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		sch, err = r.arraySchema(t)
+	case reflect.Map:
+		sch, err = r.mapSchema(t)
+	default:
+		sch, err = r.structSchema(t)
+	}
+	delete(r.visiting, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.recurses[t] {
+		name := r.defName(t)
+		r.defs[name] = sch
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+	return sch, nil
+}
+
+// defName returns unique name of t in $defs.
+func (r *reflector) defName(t reflect.Type) string {
+	if name, ok := r.defNames[t]; ok {
+		return name
+	}
+	base := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, t.Name())
+	if base == "" {
+		base = "type"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if !r.nameTaken(name) {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+	r.defNames[t] = name
+	return name
+}
+
+func (r *reflector) nameTaken(name string) bool {
+	for _, n := range r.defNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reflector) arraySchema(t reflect.Type) (map[string]any, error) {
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(textMarshalerType) {
+		return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+	}
+	items, err := r.schema(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	sch := map[string]any{"type": "array", "items": items}
+	if t.Kind() == reflect.Array {
+		n := json.Number(strconv.Itoa(t.Len()))
+		sch["minItems"], sch["maxItems"] = n, n
+	}
+	return sch, nil
+}
+
+func (r *reflector) mapSchema(t reflect.Type) (map[string]any, error) {
+	sch := map[string]any{"type": "object"}
+	switch t.Key().Kind() {
+	case reflect.String:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sch["propertyNames"] = map[string]any{"pattern": "^-?[0-9]+$"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sch["propertyNames"] = map[string]any{"pattern": "^[0-9]+$"}
+	default:
+		if !t.Key().Implements(textMarshalerType) {
+			return nil, &UnsupportedValueError{Type: t.String()}
+		}
+	}
+	additional, err := r.schema(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	sch["additionalProperties"] = additional
+	return sch, nil
+}
+
+func (r *reflector) structSchema(t reflect.Type) (map[string]any, error) {
+	fields := structFields(t)
+	var own []goField
+	for _, f := range fields {
+		if len(f.index) == 1 {
+			own = append(own, f)
+		}
+	}
+	sch, err := r.fieldsSchema(t, own)
+	if err != nil {
+		return nil, err
+	}
+	sch["type"] = "object"
+
+	// embedded structs
+	var allOf []any
+	for i := 0; i < t.NumField(); i++ {
+		var promoted []goField
+		for _, f := range fields {
+			if len(f.index) > 1 && f.index[0] == i {
+				promoted = append(promoted, f)
+			}
+		}
+		if len(promoted) == 0 {
+			continue
+		}
+		ft := t.Field(i).Type
+		var item map[string]any
+		if ft.Kind() != reflect.Pointer && samePromoted(structFields(ft), promoted) {
+			item, err = r.schema(ft)
+		} else {
+			// some fields are shadowed by t, or not required
+			// because they are omitted when pointer is nil
+			item, err = r.fieldsSchema(t, promoted)
+		}
+		if err != nil {
+			return nil, err
+		}
+		allOf = append(allOf, item)
+	}
+	if len(allOf) > 0 {
+		sch["allOf"] = allOf
+	}
+	return sch, nil
+}
+
+// fieldsSchema returns schema with given fields of struct t
+// as properties.
+func (r *reflector) fieldsSchema(t reflect.Type, fields []goField) (map[string]any, error) {
+	props := map[string]any{}
+	var required []any
+	for _, f := range fields {
+		sf := t.FieldByIndex(f.index)
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		sch, err := r.schema(sf.Type)
+		if err != nil {
+			return nil, err
+		}
+		if f.quoted {
+			switch ft.Kind() {
+			case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64, reflect.String:
+				sch = map[string]any{"type": "string"}
+				if sf.Type.Kind() == reflect.Pointer {
+					sch = nullable(sch)
+				}
+			}
+		}
+		_, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		omitEmpty := f.omitEmpty || slices.Contains(strings.Split(opts, ","), "omitzero")
+
+		isRequired, err := applyTag(sch, sf.Tag.Get("jsonschema"))
+		if err != nil {
+			return nil, &InvalidTagError{Type: t.String(), Field: sf.Name, Err: err}
+		}
+		props[f.name] = sch
+		if isRequired || !omitEmpty && !promotedViaPointer(t, f.index) {
+			required = append(required, f.name)
+		}
+	}
+
+	sch := map[string]any{}
+	if len(props) > 0 {
+		sch["properties"] = props
+	}
+	if len(required) > 0 {
+		sch["required"] = required
+	}
+	return sch, nil
+}
+
+// samePromoted tells whether promoted, the fields of struct
+// promoted through its embedded struct, are same as fields,
+// the fields of that embedded struct.
+func samePromoted(fields, promoted []goField) bool {
+	return slices.EqualFunc(fields, promoted, func(f, p goField) bool {
+		return f.name == p.name && slices.Equal(f.index, p.index[1:])
+	})
+}
+
+// promotedViaPointer tells whether the field of struct t at index
+// is promoted through embedded pointer. Such field is omitted by
+// encoding/json, when the pointer is nil.
+func promotedViaPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable returns sch modified to allow null.
+func nullable(sch map[string]any) map[string]any {
+	switch typ := sch["type"].(type) {
+	case string:
+		if typ != "null" {
+			sch["type"] = []any{typ, "null"}
+		}
+		return sch
+	case []any:
+		for _, t := range typ {
+			if t == "null" {
+				return sch
+			}
+		}
+		sch["type"] = append(typ, "null")
+		return sch
+	}
+	if len(sch) == 0 {
+		return sch
+	}
+	return map[string]any{"anyOf": []any{sch, map[string]any{"type": "null"}}}
+}
+
+// applyTag adds the keywords in jsonschema struct tag to sch.
+// It reports whether the tag has required flag.
+func applyTag(sch map[string]any, tag string) (required bool, err error) {
+	if tag == "" {
+		return false, nil
+	}
+	for _, item := range splitTag(tag) {
+		key, value, hasValue := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		switch key {
+		case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties",
+			"minContains", "maxContains", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+			"multipleOf", "order":
+			if !isJSONNumber(value) {
+				return false, fmt.Errorf("%s: %q is not a number", key, value)
+			}
+			sch[key] = json.Number(value)
+		case "title", "description", "pattern", "format", "name", "$comment", "contentEncoding", "contentMediaType":
+			sch[key] = value
+		case "uniqueItems", "readOnly", "writeOnly", "deprecated":
+			b := true
+			if hasValue {
+				if b, err = strconv.ParseBool(value); err != nil {
+					return false, fmt.Errorf("%s: %q is not a boolean", key, value)
+				}
+			}
+			sch[key] = b
+		case "default", "const":
+			sch[key] = tagValue(value)
+		case "enum":
+			var enum []any
+			for _, v := range strings.Split(value, "|") {
+				enum = append(enum, tagValue(v))
+			}
+			sch[key] = enum
+		case "required":
+			required = true
+		case "nullable":
+			if _, ok := sch["type"]; !ok {
+				return false, fmt.Errorf("nullable: schema has no type")
+			}
+			nullable(sch)
+		default:
+			return false, fmt.Errorf("unknown key %q", key)
+		}
+	}
+	return required, nil
+}
+
+// splitTag splits tag at commas, that are not escaped with backslash.
+func splitTag(tag string) []string {
+	var items []string
+	var sb strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			sb.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			items = append(items, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(tag[i])
+		}
+	}
+	return append(items, sb.String())
+}
+
+// tagValue returns s parsed as json, if valid, else s.
+func tagValue(s string) any {
+	if v, err := UnmarshalJSON(strings.NewReader(s)); err == nil {
+		return v
+	}
+	return s
+}
+
+// InvalidTagError is returned by [Reflect] if jsonschema
+// struct tag of a field is invalid.
+type InvalidTagError struct {
+	// Type is the struct type.
+	Type string
+
+	// Field is the name of the struct field.
+	Field string
+
+	Err error
+}
+
+func (e *InvalidTagError) Error() string {
+	return fmt.Sprintf("invalid jsonschema tag on %s.%s: %v", e.Type, e.Field, e.Err)
+}
+
+func (e *InvalidTagError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,180 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+type reflectBase struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type reflectPerson struct {
+	reflectBase
+	Name     string            `json:"name" jsonschema:"minLength=3,order=2,title=Full Name\\, as in passport"`
+	Age      *int              `json:"age,omitempty" jsonschema:"minimum=0,order=1"`
+	Email    string            `json:"email,omitempty" jsonschema:"format=email,required"`
+	Role     string            `json:"role,omitempty" jsonschema:"enum=admin|user,default=user"`
+	Tags     []string          `json:"tags,omitempty" jsonschema:"uniqueItems"`
+	Labels   map[string]string `json:"-"`
+	Score    float64           `json:",string,omitempty"`
+	Parent   *reflectPerson    `json:"parent,omitempty"`
+	Children []reflectNode     `json:"children,omitempty"`
+	internal int
+}
+
+type reflectNode struct {
+	Value int          `json:"value"`
+	Next  *reflectNode `json:"next"`
+}
+
+func TestReflect(t *testing.T) {
+	doc, err := jsonschema.Reflect(reflect.TypeOf(reflectPerson{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"allOf": [{
+			"type": "object",
+			"properties": {
+				"id": { "type": "string" },
+				"created": { "type": "datetime" }
+			},
+			"required": ["id", "created"]
+		}],
+		"properties": {
+			"name": { "type": "string", "minLength": 3, "order": 2, "title": "Full Name, as in passport" },
+			"age": { "type": ["integer", "null"], "minimum": 0, "order": 1 },
+			"email": { "type": "string", "format": "email" },
+			"role": { "type": "string", "enum": ["admin", "user"], "default": "user" },
+			"tags": { "type": "array", "items": { "type": "string" }, "uniqueItems": true },
+			"Score": { "type": "string" },
+			"parent": { "anyOf": [{ "$ref": "#" }, { "type": "null" }] },
+			"children": { "type": "array", "items": { "$ref": "#/$defs/reflectNode" } }
+		},
+		"required": ["name", "email"],
+		"$defs": {
+			"reflectNode": {
+				"type": "object",
+				"properties": {
+					"value": { "type": "integer" },
+					"next": { "anyOf": [{ "$ref": "#/$defs/reflectNode" }, { "type": "null" }] }
+				},
+				"required": ["value", "next"]
+			}
+		}
+	}`
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmarshalString(t, string(got)), unmarshalString(t, want)) {
+		t.Fatalf("got %s", got)
+	}
+}
+
+type reflectShadowBase struct {
+	Name string `json:"name" jsonschema:"maxLength=3"`
+	Code int    `json:"code"`
+}
+
+type reflectShadowExtra struct {
+	Note string `json:"note"`
+}
+
+type reflectShadow struct {
+	reflectShadowBase
+	*reflectShadowExtra
+	Name string `json:"name" jsonschema:"minLength=5"`
+}
+
+func TestReflectEmbedded(t *testing.T) {
+	doc, err := jsonschema.Reflect(reflect.TypeOf(reflectShadow{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": { "type": "string", "minLength": 5 }
+		},
+		"required": ["name"],
+		"allOf": [
+			{ "properties": { "code": { "type": "integer" } }, "required": ["code"] },
+			{ "properties": { "note": { "type": "string" } } }
+		]
+	}`
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmarshalString(t, string(got)), unmarshalString(t, want)) {
+		t.Fatalf("got %s", got)
+	}
+
+	// schema must accept what json.Marshal emits
+	c := jsonschema.NewCompiler()
+	sch, err := c.CompileType("shadow.json", reflect.TypeOf(reflectShadow{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := reflectShadow{reflectShadowBase: reflectShadowBase{Name: "x", Code: 1}, Name: "long name"}
+	if err := sch.Validate(v); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompileType(t *testing.T) {
+	c := jsonschema.NewCompiler()
+	sch, err := c.CompileType("person.json", reflect.TypeOf(reflectPerson{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sch.Properties["name"].Order == nil || *sch.Properties["name"].Order != 2 {
+		t.Error("order of name must be 2")
+	}
+
+	age := 30
+	valid := reflectPerson{
+		reflectBase: reflectBase{ID: "p1", Created: time.Now()},
+		Name:        "John",
+		Age:         &age,
+		Email:       "john@example.com",
+		Children:    []reflectNode{{Value: 1, Next: &reflectNode{Value: 2}}},
+		Parent:      &reflectPerson{Name: "Jane", Email: "jane@example.com"},
+	}
+	if err := sch.Validate(valid); err != nil {
+		t.Fatalf("valid: %v", err)
+	}
+	invalid := valid
+	invalid.Name = "Jo"
+	if err := sch.Validate(invalid); err == nil {
+		t.Fatal("name with 2 chars must be invalid")
+	}
+	if err := sch.Validate(unmarshalString(t, `{"id": "p1", "created": "2024-01-01T00:00:00Z", "name": "John", "email": "a@b.c", "children": [{"value": 1, "next": {"value": "2", "next": null}}]}`)); err == nil {
+		t.Fatal("string value in nested node must be invalid")
+	}
+}
+
+func TestReflectErrors(t *testing.T) {
+	if _, err := jsonschema.Reflect(reflect.TypeOf(struct{ C chan int }{})); err == nil {
+		t.Error("chan must not be supported")
+	}
+	_, err := jsonschema.Reflect(reflect.TypeOf(struct {
+		Name string `jsonschema:"minLength=abc"`
+	}{}))
+	var terr *jsonschema.InvalidTagError
+	if !errors.As(err, &terr) || terr.Field != "Name" || !strings.Contains(err.Error(), "minLength") {
+		t.Errorf("got %v, want InvalidTagError", err)
+	}
+}
@@ -0,0 +1,230 @@
+package jsonschema
+
+import (
+	"golang.org/x/text/message"
+)
+
+// Result is the outcome of [Schema.Evaluate]. Unlike [ValidationError],
+// it is available for valid instances also.
+type Result struct {
+	err  error
+	root *traceNode
+}
+
+// Evaluate validates v and returns the result, that records
+// every subschema and keyword evaluated.
+//
+// It is slower than [Schema.Validate], and it is intended for
+// auditing which constraints are checked on an instance.
+func (sch *Schema) Evaluate(v any) *Result {
+	eval := &evaluation{collectAnnotations: true, trace: &tracer{}}
+	err := sch.validate(v, nil, nil, nil, false, nil, eval)
+	return &Result{err, eval.trace.root}
+}
+
+// Valid tells whether the instance is valid.
+func (r *Result) Valid() bool {
+	return r.err == nil
+}
+
+// Err returns the *ValidationError, if the instance is not valid.
+// Otherwise it returns nil.
+func (r *Result) Err() error {
+	return r.err
+}
+
+// The `Verbose` structure, based on the schema. It has output unit for
+// every subschema evaluated, along with the output units of its keywords
+// whether they are valid or not. Annotations are reported for keywords
+// such as title. Nested units of a valid unit are listed in annotations,
+// and nested units of an invalid unit are listed in errors.
+func (r *Result) VerboseOutput() *OutputUnit {
+	return r.LocalizedVerboseOutput(defaultPrinter)
+}
+
+// LocalizedVerboseOutput is like [Result.VerboseOutput], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
+func (r *Result) LocalizedVerboseOutput(p *message.Printer) *OutputUnit {
+	p = localize(p)
+	out := r.root.verboseOutput(p)
+	return &out
+}
+
+func (node *traceNode) verboseOutput(p *message.Printer) OutputUnit {
+	out := OutputUnit{
+		Valid:                   node.err == nil,
+		KeywordLocation:         node.kwLoc,
+		AbsoluteKeywordLocation: node.sch.Location,
+		InstanceLocation:        jsonPtr(node.vloc),
+	}
+
+	var units []OutputUnit
+
+	// keywords --
+	failed := node.failedKeywords()
+	for _, err := range node.keywordErrors() {
+		units = append(units, OutputUnit{
+			Valid:                   false,
+			KeywordLocation:         node.kwLoc + jsonPtr(err.ErrorKind.KeywordPath()),
+			AbsoluteKeywordLocation: err.absoluteKeywordLocation(),
+			InstanceLocation:        jsonPtr(err.InstanceLocation),
+			Error:                   &OutputError{err.ErrorKind, err.Message, p},
+			ErrorInfo:               NewErrorInfo(err.ErrorKind),
+		})
+	}
+	if node.err == nil || node.complete {
+		annotations := map[string]any{}
+		var annotKeywords []string
+		for _, a := range node.validAnnotations() {
+			if _, ok := annotations[a.Keyword]; !ok {
+				annotKeywords = append(annotKeywords, a.Keyword)
+			}
+			annotations[a.Keyword] = a.Value
+		}
+		keywordUnit := func(kw string) OutputUnit {
+			return OutputUnit{
+				Valid:                   true,
+				KeywordLocation:         node.kwLoc + "/" + escape(kw),
+				AbsoluteKeywordLocation: node.sch.Location + "/" + encode(escape(kw)),
+				InstanceLocation:        out.InstanceLocation,
+				Annotation:              annotations[kw],
+			}
+		}
+		done := map[string]bool{}
+		for _, kw := range node.sch.evaluatedKeywords(node.v) {
+			if !failed[kw] {
+				units = append(units, keywordUnit(kw))
+			}
+			done[kw] = true
+		}
+		for _, kw := range annotKeywords {
+			if !done[kw] {
+				units = append(units, keywordUnit(kw)) // from vocabularies
+			}
+		}
+	}
+
+	// subschemas --
+	for _, child := range node.children {
+		units = append(units, child.verboseOutput(p))
+	}
+
+	if out.Valid {
+		out.Annotations = units
+	} else {
+		out.Errors = units
+	}
+	return out
+}
+
+// --
+
+// EvaluationUnit is the output unit of list and hierarchical
+// output formats.
+type EvaluationUnit struct {
+	Valid            bool   `json:"valid"`
+	EvaluationPath   string `json:"evaluationPath"`
+	SchemaLocation   string `json:"schemaLocation"`
+	InstanceLocation string `json:"instanceLocation"`
+
+	// Errors maps keyword to its error message.
+	Errors map[string]string `json:"errors,omitempty"`
+
+	// Annotations maps keyword to its annotation value.
+	Annotations map[string]any `json:"annotations,omitempty"`
+
+	// DroppedAnnotations are the annotations of invalid unit.
+	DroppedAnnotations map[string]any `json:"droppedAnnotations,omitempty"`
+
+	// Details holds nested units.
+	Details []EvaluationUnit `json:"details,omitempty"`
+}
+
+// ListOutput is the `List` output format.
+type ListOutput struct {
+	Valid   bool             `json:"valid"`
+	Details []EvaluationUnit `json:"details,omitempty"`
+}
+
+// The `List` format, a flat list of the units, that have
+// errors, annotations or dropped annotations.
+func (r *Result) ListOutput() *ListOutput {
+	return r.LocalizedListOutput(defaultPrinter)
+}
+
+// LocalizedListOutput is like [Result.ListOutput], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
+func (r *Result) LocalizedListOutput(p *message.Printer) *ListOutput {
+	p = localize(p)
+	out := &ListOutput{Valid: r.Valid()}
+	var flatten func(node *traceNode)
+	flatten = func(node *traceNode) {
+		unit := node.evaluationUnit(p)
+		if unit.Errors != nil || unit.Annotations != nil || unit.DroppedAnnotations != nil {
+			out.Details = append(out.Details, unit)
+		}
+		for _, child := range node.children {
+			flatten(child)
+		}
+	}
+	flatten(r.root)
+	return out
+}
+
+// The `Hierarchical` format, a tree of units based on
+// the evaluation path.
+func (r *Result) HierarchicalOutput() *EvaluationUnit {
+	return r.LocalizedHierarchicalOutput(defaultPrinter)
+}
+
+// LocalizedHierarchicalOutput is like [Result.HierarchicalOutput], but in the language of p.
+// p may be created by [NewPrinter], or by [message.NewPrinter]
+// with the default catalog.
+func (r *Result) LocalizedHierarchicalOutput(p *message.Printer) *EvaluationUnit {
+	p = localize(p)
+	var build func(node *traceNode) EvaluationUnit
+	build = func(node *traceNode) EvaluationUnit {
+		unit := node.evaluationUnit(p)
+		for _, child := range node.children {
+			unit.Details = append(unit.Details, build(child))
+		}
+		return unit
+	}
+	unit := build(r.root)
+	return &unit
+}
+
+// evaluationUnit returns the unit of node, without details.
+func (node *traceNode) evaluationUnit(p *message.Printer) EvaluationUnit {
+	unit := EvaluationUnit{
+		Valid:            node.err == nil,
+		EvaluationPath:   node.kwLoc,
+		SchemaLocation:   node.sch.Location,
+		InstanceLocation: jsonPtr(node.vloc),
+	}
+	for _, err := range node.keywordErrors() {
+		if unit.Errors == nil {
+			unit.Errors = map[string]string{}
+		}
+		kw := errorKeyword(err.ErrorKind)
+		msg := err.message(p)
+		if prev, ok := unit.Errors[kw]; ok {
+			msg = prev + "; " + msg
+		}
+		unit.Errors[kw] = msg
+	}
+	if len(node.annotations) > 0 {
+		annotations := map[string]any{}
+		for _, a := range node.annotations {
+			annotations[a.Keyword] = a.Value
+		}
+		if unit.Valid {
+			unit.Annotations = annotations
+		} else {
+			unit.DroppedAnnotations = annotations
+		}
+	}
+	return unit
+}
@@ -0,0 +1,153 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+// flattenUnits returns units keyed by keywordLocation.
+func flattenUnits(out jsonschema.OutputUnit, m map[string]jsonschema.OutputUnit) {
+	m[out.KeywordLocation] = out
+	for _, u := range out.Errors {
+		flattenUnits(u, m)
+	}
+	for _, u := range out.Annotations {
+		flattenUnits(u, m)
+	}
+}
+
+func TestVerboseOutput(t *testing.T) {
+	sch := compileString(t, `{
+		"title": "person",
+		"type": "object",
+		"properties": {
+			"name": { "type": "string", "minLength": 3, "description": "full name" },
+			"age": { "$ref": "#/$defs/age" }
+		},
+		"anyOf": [
+			{ "required": ["name"] },
+			{ "required": ["id"] }
+		],
+		"$defs": {
+			"age": { "type": "integer", "minimum": 0 }
+		}
+	}`)
+
+	// valid instance
+	res := sch.Evaluate(unmarshalString(t, `{"name": "bob", "age": 10}`))
+	if !res.Valid() || res.Err() != nil {
+		t.Fatalf("instance must be valid: %v", res.Err())
+	}
+	units := map[string]jsonschema.OutputUnit{}
+	flattenUnits(*res.VerboseOutput(), units)
+	tests := []struct {
+		kwLoc string
+		valid bool
+	}{
+		{"", true},
+		{"/type", true},
+		{"/title", true},
+		{"/properties", true},
+		{"/properties/name", true},
+		{"/properties/name/minLength", true},
+		{"/properties/name/description", true},
+		{"/properties/age/$ref", true},
+		{"/properties/age/$ref/minimum", true},
+		{"/anyOf/0/required", true},
+		{"/anyOf/1", false},
+		{"/anyOf/1/required", false},
+	}
+	for _, test := range tests {
+		u, ok := units[test.kwLoc]
+		if !ok {
+			t.Errorf("%q: unit not found", test.kwLoc)
+			continue
+		}
+		if u.Valid != test.valid {
+			t.Errorf("%q: got valid=%v, want %v", test.kwLoc, u.Valid, test.valid)
+		}
+	}
+	if got := units["/properties/name/description"].Annotation; got != "full name" {
+		t.Errorf("description annotation: got %v", got)
+	}
+	if got := units["/properties/age/$ref/minimum"].InstanceLocation; got != "/age" {
+		t.Errorf("instanceLocation: got %q", got)
+	}
+	if _, err := json.Marshal(res.VerboseOutput()); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid instance
+	res = sch.Evaluate(unmarshalString(t, `{"name": "al", "age": -1}`))
+	if res.Valid() {
+		t.Fatal("instance must be invalid")
+	}
+	units = map[string]jsonschema.OutputUnit{}
+	flattenUnits(*res.VerboseOutput(), units)
+	for kwLoc, valid := range map[string]bool{
+		"":                             false,
+		"/type":                        true,
+		"/properties/name/type":        true,
+		"/properties/name/minLength":   false,
+		"/properties/age/$ref/type":    true,
+		"/properties/age/$ref/minimum": false,
+	} {
+		if u, ok := units[kwLoc]; !ok || u.Valid != valid {
+			t.Errorf("%q: got %v (found=%v), want %v", kwLoc, u.Valid, ok, valid)
+		}
+	}
+	if units["/properties/name/minLength"].Error == nil {
+		t.Error("failing keyword must have error")
+	}
+}
+
+func TestListAndHierarchicalOutput(t *testing.T) {
+	sch := compileString(t, `{
+		"properties": {
+			"name": { "type": "string", "minLength": 3, "title": "name" },
+			"age": { "type": "integer", "minimum": 0, "title": "age" }
+		}
+	}`)
+	res := sch.Evaluate(unmarshalString(t, `{"name": "bob", "age": -1}`))
+	if res.Valid() {
+		t.Fatal("instance must be invalid")
+	}
+
+	list := res.ListOutput()
+	if list.Valid {
+		t.Error("list must be invalid")
+	}
+	units := map[string]jsonschema.EvaluationUnit{}
+	for _, u := range list.Details {
+		units[u.EvaluationPath] = u
+	}
+	name, ok := units["/properties/name"]
+	if !ok || !name.Valid || name.Annotations["title"] != "name" || name.DroppedAnnotations != nil {
+		t.Errorf("/properties/name: got %+v", name)
+	}
+	age, ok := units["/properties/age"]
+	if !ok || age.Valid || age.Errors["minimum"] == "" || age.Annotations != nil {
+		t.Errorf("/properties/age: got %+v", age)
+	}
+	if age.DroppedAnnotations["title"] != "age" {
+		t.Errorf("/properties/age: droppedAnnotations %v", age.DroppedAnnotations)
+	}
+	if age.InstanceLocation != "/age" {
+		t.Errorf("/properties/age: instanceLocation %q", age.InstanceLocation)
+	}
+
+	root := res.HierarchicalOutput()
+	if root.Valid || root.EvaluationPath != "" || len(root.Details) != 2 {
+		t.Fatalf("root: got %+v", root)
+	}
+	for _, d := range root.Details {
+		if d.EvaluationPath == "/properties/age" && d.Valid {
+			t.Error("/properties/age must be invalid")
+		}
+	}
+	if _, err := json.Marshal(root); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -10,15 +10,21 @@ import (
 // Schema is the regpresentation of a compiled
 // jsonschema.
 type Schema struct {
-	up                urlPtr             `json:"up"`
-	resource          *Schema            `json:"resource,omitempty"`
-	dynamicAnchors    map[string]*Schema `json:"dynamicAnchors,omitempty"`
-	allPropsEvaluated bool               `json:"allPropsEvaluated,omitempty"`
-	allItemsEvaluated bool               `json:"allItemsEvaluated,omitempty"`
-	numItemsEvaluated int                `json:"numItemsEvaluated,omitempty"`
-
-	DraftVersion int    `json:"draftVersion" json:"draftVersion,omitempty"`
-	Location     string `json:"location" json:"location,omitempty"`
+	up                urlPtr
+	resource          *Schema
+	dynamicAnchors    map[string]*Schema
+	allPropsEvaluated bool
+	allItemsEvaluated bool
+	numItemsEvaluated int
+	formatName        string         // value of format keyword, even if not asserted
+	errorMessages     *errorMessages // value of errorMessage keyword, if vocabulary is active
+
+	// values of content keywords, even if not asserted
+	contentEncodingName  string
+	contentMediaTypeName string
+
+	DraftVersion int    `json:"draftVersion,omitempty"`
+	Location     string `json:"location,omitempty"`
 
 	// type agnostic --
 	Bool            *bool       `json:"bool,omitempty"` // boolean schema
@@ -82,6 +88,12 @@ type Schema struct {
 	ExclusiveMinimum *big.Rat `json:"exclusiveMinimum,omitempty"`
 	MultipleOf       *big.Rat `json:"multipleOf,omitempty"`
 
+	// date, datetime --
+	DateMinimum          *DateBound `json:"dateMinimum,omitempty"`
+	DateMaximum          *DateBound `json:"dateMaximum,omitempty"`
+	DateExclusiveMinimum *DateBound `json:"dateExclusiveMinimum,omitempty"`
+	DateExclusiveMaximum *DateBound `json:"dateExclusiveMaximum,omitempty"`
+
 	Extensions []SchemaExt `json:"extensions,omitempty"`
 
 	// annotations --
@@ -51,6 +51,10 @@ type orderKeys struct {
 	pname string
 }
 
+func (s *orderKeys) marshalKeywords() map[string]any {
+	return map[string]any{"order": s.pname}
+}
+
 func (s *orderKeys) Validate(ctx *ValidatorContext, v any) {
 	arr, ok := v.([]any)
 	if !ok {
@@ -89,6 +93,14 @@ func (*OrderKeys) KeywordPath() []string {
 	return []string{"orderKeys"}
 }
 
+func (k *OrderKeys) ErrorParams() map[string]any {
+	return map[string]any{"key": k.Key, "duplicates": k.Duplicates}
+}
+
 func (k *OrderKeys) LocalizedString(p *message.Printer) string {
 	return p.Sprintf("order at %d and %d have same %s", k.Duplicates[0], k.Duplicates[1], k.Key)
 }
+
+func (k *OrderKeys) Error() string {
+	return k.LocalizedString(defaultPrinter)
+}
@@ -0,0 +1,232 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SourcePosition is the position of a json value in its source document.
+type SourcePosition struct {
+	// File name given to [UnmarshalJSONWithPositions].
+	File string
+
+	// Offset is 0-based byte offset.
+	Offset int
+
+	// Line and Column are 1-based. Column is counted in runes.
+	Line, Column int
+}
+
+// String returns position in `file:line:col` format.
+// file is omitted if it is empty.
+func (p SourcePosition) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// SourcePositions holds the positions of every value
+// in a json document, keyed by json-pointer.
+type SourcePositions struct {
+	file      string
+	lineStart []int // offsets, at which lines start
+	ptrs      map[string]SourcePosition
+}
+
+// Lookup returns the position of value at given json-pointer.
+func (sp *SourcePositions) Lookup(ptr string) (SourcePosition, bool) {
+	pos, ok := sp.ptrs[ptr]
+	return pos, ok
+}
+
+// nearest returns the position of value at ptr. if no such value,
+// position of nearest ancestor is returned.
+func (sp *SourcePositions) nearest(ptr string) (SourcePosition, bool) {
+	for {
+		if pos, ok := sp.ptrs[ptr]; ok {
+			return pos, true
+		}
+		slash := strings.LastIndexByte(ptr, '/')
+		if slash == -1 {
+			return SourcePosition{}, false
+		}
+		ptr = ptr[:slash]
+	}
+}
+
+func (sp *SourcePositions) position(data []byte, offset int) SourcePosition {
+	line := sort.Search(len(sp.lineStart), func(i int) bool {
+		return sp.lineStart[i] > offset
+	})
+	start := sp.lineStart[line-1]
+	return SourcePosition{
+		File:   sp.file,
+		Offset: offset,
+		Line:   line,
+		Column: utf8.RuneCount(data[start:offset]) + 1,
+	}
+}
+
+// UnmarshalJSONWithPositions is same as [UnmarshalJSON], but also
+// returns the positions of all values in the document. file is used
+// as [SourcePosition.File] of the positions.
+func UnmarshalJSONWithPositions(r io.Reader, file string) (any, *SourcePositions, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	sp := &SourcePositions{file: file, lineStart: []int{0}, ptrs: map[string]SourcePosition{}}
+	for i, b := range data {
+		if b == '\n' {
+			sp.lineStart = append(sp.lineStart, i+1)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	pd := &positionDecoder{decoder, data, sp}
+	doc, err := pd.value("")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return doc, sp, nil
+}
+
+type positionDecoder struct {
+	dec  *json.Decoder
+	data []byte
+	sp   *SourcePositions
+}
+
+// value reads next value, recording its position at ptr.
+func (pd *positionDecoder) value(ptr string) (any, error) {
+	// skip separators to find start of value
+	offset := int(pd.dec.InputOffset())
+	for offset < len(pd.data) {
+		switch pd.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+		break
+	}
+
+	tok, err := pd.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	pd.sp.ptrs[ptr] = pd.sp.position(pd.data, offset)
+
+	switch tok {
+	case json.Delim('{'):
+		obj := map[string]any{}
+		for pd.dec.More() {
+			tok, err := pd.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			pname, ok := tok.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object key %v", tok)
+			}
+			if obj[pname], err = pd.value(ptr + "/" + escape(pname)); err != nil {
+				return nil, err
+			}
+		}
+		_, err := pd.dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for pd.dec.More() {
+			item, err := pd.value(fmt.Sprintf("%s/%d", ptr, len(arr)))
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, item)
+		}
+		_, err := pd.dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+// ResolvePositions sets InstancePosition and KeywordPosition of e
+// and its causes.
+//
+// instance holds the positions of the validated instance. schemas returns
+// the positions of schema document with given url, or nil if not known.
+// Either of them can be nil. If a value is missing in the document, the
+// position of its nearest ancestor is used.
+func (e *ValidationError) ResolvePositions(instance *SourcePositions, schemas func(url string) *SourcePositions) {
+	if instance != nil {
+		if pos, ok := instance.nearest(jsonPtr(e.InstanceLocation)); ok {
+			e.InstancePosition = &pos
+		}
+	}
+	if schemas != nil {
+		u, f := split(e.SchemaURL)
+		if sp := schemas(u); sp != nil {
+			if ptr, err := decode(f); err == nil {
+				if pos, ok := sp.nearest(ptr + jsonPtr(e.ErrorKind.KeywordPath())); ok {
+					e.KeywordPosition = &pos
+				}
+			}
+		}
+	}
+	for _, cause := range e.Causes {
+		cause.ResolvePositions(instance, schemas)
+	}
+}
+
+// SchemaErrorPosition returns the position in schema document, of
+// the location reported by compile error err. It supports errors
+// *InvalidRegexError, *InvalidDateBoundError, *ParseIDError,
+// *ParseAnchorError, *DuplicateIDError and *DuplicateAnchorError;
+// for *SchemaValidationError use [ValidationError.ResolvePositions]
+// of its Err instead. schemas is same as in ResolvePositions.
+func SchemaErrorPosition(err error, schemas func(url string) *SourcePositions) (SourcePosition, bool) {
+	var u, ptr string
+	switch e := err.(type) {
+	case *InvalidRegexError:
+		u, ptr = split(e.URL)
+	case *InvalidDateBoundError:
+		u, ptr = split(e.URL)
+	case *ParseIDError:
+		u, ptr = split(e.URL)
+	case *ParseAnchorError:
+		u, ptr = split(e.URL)
+	case *DuplicateIDError:
+		return lookupPosition(schemas, e.URL, e.Ptr2)
+	case *DuplicateAnchorError:
+		return lookupPosition(schemas, e.URL, e.Ptr2)
+	default:
+		return SourcePosition{}, false
+	}
+	ptr, derr := decode(ptr)
+	if derr != nil {
+		return SourcePosition{}, false
+	}
+	return lookupPosition(schemas, u, ptr)
+}
+
+// lookupPosition returns position of json-pointer ptr in
+// document u, or of its nearest ancestor.
+func lookupPosition(schemas func(url string) *SourcePositions, u, ptr string) (SourcePosition, bool) {
+	if schemas == nil {
+		return SourcePosition{}, false
+	}
+	sp := schemas(u)
+	if sp == nil {
+		return SourcePosition{}, false
+	}
+	return sp.nearest(ptr)
+}
@@ -0,0 +1,110 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+func TestUnmarshalJSONWithPositions(t *testing.T) {
+	doc := "{\n  \"a\": [1, \"ü\", {\"b\": null}],\n\t\"c~/\" : true\n}"
+	v, sp, err := jsonschema.UnmarshalJSONWithPositions(strings.NewReader(doc), "doc.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.(map[string]any)["a"]; !ok {
+		t.Fatalf("got %v", v)
+	}
+	tests := []struct {
+		ptr  string
+		want string
+	}{
+		{"", "doc.json:1:1"},
+		{"/a", "doc.json:2:8"},
+		{"/a/0", "doc.json:2:9"},
+		{"/a/1", "doc.json:2:12"},
+		{"/a/2", "doc.json:2:17"},
+		{"/a/2/b", "doc.json:2:23"},
+		{"/c~0~1", "doc.json:3:10"},
+	}
+	for _, test := range tests {
+		pos, ok := sp.Lookup(test.ptr)
+		if !ok {
+			t.Errorf("%q: not found", test.ptr)
+			continue
+		}
+		if got := pos.String(); got != test.want {
+			t.Errorf("%q: got %s, want %s", test.ptr, got, test.want)
+		}
+	}
+
+	if _, _, err := jsonschema.UnmarshalJSONWithPositions(strings.NewReader(`{} []`), ""); err == nil {
+		t.Error("trailing data must fail")
+	}
+}
+
+func TestResolvePositions(t *testing.T) {
+	schema := "{\n  \"properties\": {\n    \"name\": { \"minLength\": 3 }\n  }\n}"
+	doc, schPos, err := jsonschema.UnmarshalJSONWithPositions(strings.NewReader(schema), "schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("http://example.com/schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inst, instPos, err := jsonschema.UnmarshalJSONWithPositions(strings.NewReader("{\n \"name\": \"ab\"\n}"), "inst.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sch.Validate(inst)
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		t.Fatalf("got %v, want *ValidationError", err)
+	}
+	verr.ResolvePositions(instPos, func(url string) *jsonschema.SourcePositions {
+		if url == "http://example.com/schema.json" {
+			return schPos
+		}
+		return nil
+	})
+	want := "at '/name' (inst.json:2:10) [schema.json:3:28]"
+	if !strings.Contains(verr.Error(), want) {
+		t.Errorf("error must contain %q:\n%v", want, verr)
+	}
+	leaf := verr.BasicOutput().Errors[0]
+	if leaf.InstancePosition != "inst.json:2:10" || leaf.KeywordPosition != "schema.json:3:28" {
+		t.Errorf("got positions %q %q", leaf.InstancePosition, leaf.KeywordPosition)
+	}
+}
+
+func TestSchemaErrorPosition(t *testing.T) {
+	schema := "{\n  \"$defs\": {\n    \"a\": { \"$anchor\": \"x\" },\n    \"b\": { \"$anchor\": \"x\" }\n  }\n}"
+	doc, schPos, err := jsonschema.UnmarshalJSONWithPositions(strings.NewReader(schema), "schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("http://example.com/schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Compile("http://example.com/schema.json")
+	if _, ok := err.(*jsonschema.DuplicateAnchorError); !ok {
+		t.Fatalf("got %v, want *DuplicateAnchorError", err)
+	}
+	pos, ok := jsonschema.SchemaErrorPosition(err, func(url string) *jsonschema.SourcePositions {
+		if url == "http://example.com/schema.json" {
+			return schPos
+		}
+		return nil
+	})
+	if got := pos.String(); !ok || got != "schema.json:3:10" && got != "schema.json:4:10" {
+		t.Errorf("got %q, want position of $defs/a or $defs/b", got)
+	}
+}
@@ -0,0 +1,451 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// ValidateReader validates the json document read from r, without
+// decoding the whole document into memory.
+//
+// Objects and arrays are validated incrementally as their tokens are
+// read, if their schema uses only the keywords that permit it: type,
+// properties, patternProperties, additionalProperties, propertyNames,
+// required, dependentRequired, minProperties, maxProperties, items,
+// prefixItems, additionalItems, minItems, maxItems and $ref to such
+// schema. Any other keyword, for example uniqueItems, contains, enum,
+// allOf or unevaluatedProperties, requires the value to be decoded
+// fully; in such case only that value is buffered and validated as
+// in [Schema.Validate]. Scalar values are always validated as in
+// [Schema.Validate].
+//
+// Errors in reading or parsing json are returned as they are.
+func (sch *Schema) ValidateReader(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	st := &streamer{dec: dec, eval: &evaluation{}}
+	verr, err := st.validate(sch, make([]string, 0, 8), nil)
+	if err != nil {
+		return err
+	}
+	switch _, err := dec.Token(); err {
+	case io.EOF:
+	case nil:
+		return fmt.Errorf("invalid character after top-level value")
+	default:
+		return err
+	}
+	if verr == nil {
+		return nil
+	}
+	var causes []*ValidationError
+	if _, ok := verr.ErrorKind.(*kind.Group); ok && verr.Message == "" {
+		causes = verr.Causes
+	} else {
+		causes = []*ValidationError{verr}
+	}
+	return &ValidationError{
+		SchemaURL:        sch.Location,
+		InstanceLocation: nil,
+		ErrorKind:        &kind.Schema{Location: sch.Location},
+		Causes:           causes,
+	}
+}
+
+type streamer struct {
+	dec  *json.Decoder
+	eval *evaluation
+	vid  int // id of value being read
+}
+
+// validate reads next value from decoder and validates it with sch.
+func (st *streamer) validate(sch *Schema, vloc []string, parent *scope) (*ValidationError, error) {
+	tok, err := st.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	st.vid++
+	scp := &scope{sch, "", st.vid, nil}
+	if parent != nil {
+		scp = parent.child(sch, "", st.vid)
+	}
+
+	if tok == json.Delim('{') || tok == json.Delim('[') {
+		if sch.Bool != nil {
+			if err := st.skip(tok); err != nil {
+				return nil, err
+			}
+			if *sch.Bool {
+				return nil, nil
+			}
+			return st.error(sch, vloc, &kind.FalseSchema{}), nil
+		}
+		if s, scp := streamTarget(sch, scp); s != nil {
+			if tok == json.Delim('{') {
+				return st.object(s, vloc, scp)
+			}
+			return st.array(s, vloc, scp)
+		}
+	}
+
+	// buffer the value
+	v, err := st.read(tok)
+	if err != nil {
+		return nil, err
+	}
+	return st.validateValue(sch, v, vloc, scp), nil
+}
+
+// validateValue validates v fully decoded.
+func (st *streamer) validateValue(sch *Schema, v any, vloc []string, scp *scope) *ValidationError {
+	vd := validator{
+		v:            v,
+		vloc:         vloc,
+		sch:          sch,
+		scp:          scp,
+		uneval:       unevalFrom(v, sch, false),
+		errors:       nil,
+		boolResult:   false,
+		regexpEngine: nil,
+		meta:         nil,
+		resources:    nil,
+		assertVocabs: false,
+		vocabularies: nil,
+		eval:         st.eval,
+	}
+	if _, err := vd.validate(); err != nil {
+		return err.(*ValidationError)
+	}
+	return nil
+}
+
+func (st *streamer) object(s *Schema, vloc []string, scp *scope) (*ValidationError, error) {
+	if s.Types != nil && !s.Types.IsEmpty() && !s.Types.contains(objectType) {
+		if err := st.skip(json.Delim('{')); err != nil {
+			return nil, err
+		}
+		return st.error(s, vloc, &kind.Type{Got: objectType.String(), Want: s.Types.ToStrings()}), nil
+	}
+
+	var errors []*ValidationError
+	addErr := func(verr *ValidationError) {
+		if verr != nil {
+			errors = append(errors, verr)
+		}
+	}
+
+	obj := map[string]any{} // properties seen, with nil values
+	var additionalProps []string
+	for st.dec.More() {
+		tok, err := st.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		pname, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid object key %v", tok)
+		}
+		obj[pname] = nil
+		vloc := append(vloc, pname)
+
+		// propertyNames --
+		if s.PropertyNames != nil {
+			if err := s.PropertyNames.validate(pname, nil, nil, nil, false, nil, &evaluation{}); err != nil {
+				verr := err.(*ValidationError)
+				verr.SchemaURL = s.PropertyNames.Location
+				verr.InstanceLocation = append([]string(nil), vloc[:len(vloc)-1]...)
+				verr.ErrorKind = &kind.PropertyNames{Property: pname}
+				addErr(verr)
+			}
+		}
+
+		// properties, patternProperties, additionalProperties --
+		var schemas []*Schema
+		if sch, ok := s.Properties[pname]; ok {
+			schemas = append(schemas, sch)
+		}
+		for regex, sch := range s.PatternProperties {
+			if regex.MatchString(pname) {
+				schemas = append(schemas, sch)
+			}
+		}
+		if len(schemas) == 0 {
+			switch additional := s.AdditionalProperties.(type) {
+			case bool:
+				if !additional {
+					additionalProps = append(additionalProps, pname)
+				}
+			case *Schema:
+				schemas = append(schemas, additional)
+			}
+		}
+
+		switch len(schemas) {
+		case 0:
+			if err := st.skipNext(); err != nil {
+				return nil, err
+			}
+		case 1:
+			verr, err := st.validate(schemas[0], vloc, scp)
+			if err != nil {
+				return nil, err
+			}
+			addErr(verr)
+		default:
+			v, err := st.readNext()
+			if err != nil {
+				return nil, err
+			}
+			st.vid++
+			for _, sch := range schemas {
+				addErr(st.validateValue(sch, v, vloc, scp.child(sch, "", st.vid)))
+			}
+		}
+	}
+	if _, err := st.dec.Token(); err != nil { // '}'
+		return nil, err
+	}
+
+	if len(additionalProps) > 0 {
+		addErr(st.error(s, vloc, &kind.AdditionalProperties{Properties: additionalProps}))
+	}
+
+	// check keywords that need only property names --
+	vd := validator{v: obj, vloc: vloc, sch: s, scp: scp, uneval: &uneval{}, eval: st.eval}
+	if s.MinProperties != nil && len(obj) < *s.MinProperties {
+		vd.addError(&kind.MinProperties{Got: len(obj), Want: *s.MinProperties})
+	}
+	if s.MaxProperties != nil && len(obj) > *s.MaxProperties {
+		vd.addError(&kind.MaxProperties{Got: len(obj), Want: *s.MaxProperties})
+	}
+	if missing := vd.findMissing(obj, s.Required); missing != nil {
+		vd.addError(&kind.Required{Missing: missing})
+	}
+	for pname, dep := range s.Dependencies {
+		if _, ok := obj[pname]; ok {
+			if missing := vd.findMissing(obj, dep.([]string)); missing != nil {
+				vd.addError(&kind.Dependency{Prop: pname, Missing: missing})
+			}
+		}
+	}
+	for pname, reqd := range s.DependentRequired {
+		if _, ok := obj[pname]; ok {
+			if missing := vd.findMissing(obj, reqd); missing != nil {
+				vd.addError(&kind.DependentRequired{Prop: pname, Missing: missing})
+			}
+		}
+	}
+	errors = append(vd.errors, errors...)
+
+	return st.result(s, vloc, errors), nil
+}
+
+func (st *streamer) array(s *Schema, vloc []string, scp *scope) (*ValidationError, error) {
+	if s.Types != nil && !s.Types.IsEmpty() && !s.Types.contains(arrayType) {
+		if err := st.skip(json.Delim('[')); err != nil {
+			return nil, err
+		}
+		return st.error(s, vloc, &kind.Type{Got: arrayType.String(), Want: s.Types.ToStrings()}), nil
+	}
+
+	var errors []*ValidationError
+	n := 0
+	additionalItems := 0
+	for ; st.dec.More(); n++ {
+		var sch *Schema
+		if s.DraftVersion < 2020 {
+			switch items := s.Items.(type) {
+			case *Schema:
+				sch = items
+			case []*Schema:
+				if n < len(items) {
+					sch = items[n]
+				} else {
+					switch additional := s.AdditionalItems.(type) {
+					case bool:
+						if !additional {
+							additionalItems++
+						}
+					case *Schema:
+						sch = additional
+					}
+				}
+			}
+		} else if n < len(s.PrefixItems) {
+			sch = s.PrefixItems[n]
+		} else {
+			sch = s.Items2020
+		}
+
+		if sch == nil {
+			if err := st.skipNext(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		verr, err := st.validate(sch, append(vloc, strconv.Itoa(n)), scp)
+		if err != nil {
+			return nil, err
+		}
+		if verr != nil {
+			errors = append(errors, verr)
+		}
+	}
+	if _, err := st.dec.Token(); err != nil { // ']'
+		return nil, err
+	}
+
+	var errs []*ValidationError
+	if s.MinItems != nil && n < *s.MinItems {
+		errs = append(errs, st.error(s, vloc, &kind.MinItems{Got: n, Want: *s.MinItems}))
+	}
+	if s.MaxItems != nil && n > *s.MaxItems {
+		errs = append(errs, st.error(s, vloc, &kind.MaxItems{Got: n, Want: *s.MaxItems}))
+	}
+	if additionalItems > 0 {
+		errs = append(errs, st.error(s, vloc, &kind.AdditionalItems{Count: additionalItems}))
+	}
+	errors = append(errs, errors...)
+
+	return st.result(s, vloc, errors), nil
+}
+
+// streamTarget returns the schema, with which an object or array can
+// be validated incrementally. $ref is followed if it is the only
+// keyword. It returns nil, if value must be buffered.
+func streamTarget(sch *Schema, scp *scope) (*Schema, *scope) {
+	for hops := 0; hops < 32; hops++ {
+		s := sch
+		if s.Bool != nil ||
+			s.Enum != nil || s.Const != nil || s.Format != nil ||
+			s.Not != nil || s.AllOf != nil || s.AnyOf != nil || s.OneOf != nil || s.If != nil ||
+			s.RecursiveRef != nil || s.DynamicRef != nil || len(s.Extensions) > 0 ||
+			s.DependentSchemas != nil || s.UnevaluatedProperties != nil ||
+			s.UniqueItems || s.Contains != nil || s.UnevaluatedItems != nil {
+			return nil, nil
+		}
+		for _, dep := range s.Dependencies {
+			if _, ok := dep.([]string); !ok {
+				return nil, nil
+			}
+		}
+		if s.Ref == nil {
+			return s, scp
+		}
+		if s.DraftVersion >= 2019 && s.hasStreamKeywords() {
+			// validated with two schemas
+			return nil, nil
+		}
+		sch = s.Ref
+		scp = scp.child(sch, "$ref", scp.vid)
+	}
+	return nil, nil
+}
+
+// hasStreamKeywords tells whether s has any keyword
+// that is validated while streaming.
+func (s *Schema) hasStreamKeywords() bool {
+	return s.Types != nil ||
+		s.Properties != nil || s.PatternProperties != nil || s.AdditionalProperties != nil ||
+		s.PropertyNames != nil || s.Required != nil || s.Dependencies != nil || s.DependentRequired != nil ||
+		s.MinProperties != nil || s.MaxProperties != nil ||
+		s.Items != nil || s.AdditionalItems != nil || s.PrefixItems != nil || s.Items2020 != nil ||
+		s.MinItems != nil || s.MaxItems != nil
+}
+
+func (st *streamer) error(s *Schema, vloc []string, k ErrorKind) *ValidationError {
+	return &ValidationError{
+		SchemaURL:        s.Location,
+		InstanceLocation: append([]string(nil), vloc...),
+		ErrorKind:        k,
+		sch:              s,
+	}
+}
+
+func (st *streamer) result(s *Schema, vloc []string, errors []*ValidationError) *ValidationError {
+	switch len(errors) {
+	case 0:
+		return nil
+	case 1:
+		return errors[0]
+	default:
+		verr := st.error(s, vloc, &kind.Group{})
+		verr.Causes = errors
+		return verr
+	}
+}
+
+// read returns the value starting with tok.
+func (st *streamer) read(tok json.Token) (any, error) {
+	switch tok {
+	case json.Delim('{'):
+		obj := map[string]any{}
+		for st.dec.More() {
+			tok, err := st.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			pname, ok := tok.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object key %v", tok)
+			}
+			if obj[pname], err = st.readNext(); err != nil {
+				return nil, err
+			}
+		}
+		_, err := st.dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for st.dec.More() {
+			item, err := st.readNext()
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, item)
+		}
+		_, err := st.dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+func (st *streamer) readNext() (any, error) {
+	tok, err := st.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	return st.read(tok)
+}
+
+// skip consumes the value starting with tok.
+func (st *streamer) skip(tok json.Token) error {
+	depth := 0
+	for {
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+		var err error
+		if tok, err = st.dec.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+func (st *streamer) skipNext() error {
+	tok, err := st.dec.Token()
+	if err != nil {
+		return err
+	}
+	return st.skip(tok)
+}
@@ -0,0 +1,113 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/liuxd6825/jsonschema/v6"
+)
+
+// leafErrors returns sorted "instanceLocation: message" of leaf errors.
+func leafErrors(err error) []string {
+	var leaves []string
+	var collect func(verr *jsonschema.ValidationError)
+	collect = func(verr *jsonschema.ValidationError) {
+		if len(verr.Causes) == 0 {
+			leaves = append(leaves, fmt.Sprintf("/%s: %s", strings.Join(verr.InstanceLocation, "/"), verr.ErrorKind))
+		}
+		for _, cause := range verr.Causes {
+			collect(cause)
+		}
+	}
+	if verr, ok := err.(*jsonschema.ValidationError); ok {
+		collect(verr)
+	}
+	sort.Strings(leaves)
+	return leaves
+}
+
+func TestValidateReader(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "object",
+		"properties": {
+			"id": { "type": "integer" },
+			"name": { "type": "string", "minLength": 3 },
+			"tags": { "type": "array", "items": { "type": "string" }, "maxItems": 2 },
+			"ids": { "type": "array", "uniqueItems": true },
+			"point": { "$ref": "#/$defs/point" },
+			"kind": { "enum": ["a", "b"] }
+		},
+		"patternProperties": {
+			"^x-": { "type": "integer" },
+			"^x-n": { "minimum": 10 }
+		},
+		"additionalProperties": false,
+		"required": ["name", "id"],
+		"maxProperties": 6,
+		"$defs": {
+			"point": {
+				"type": "array",
+				"prefixItems": [{ "type": "number" }, { "type": "number" }],
+				"items": false
+			}
+		}
+	}`)
+
+	tests := []string{
+		`{ "name": "bob", "id": 1 }`,
+		`{ "name": "b", "tags": ["a", 1, "c"] }`,
+		`{ "name": "bob", "id": 1, "ids": [1, 2, 1], "kind": "c" }`,
+		`{ "name": "bob", "id": 1, "point": [1, "2", 3] }`,
+		`{ "name": "bob", "id": 1, "x-a": "s", "x-n": 5, "other": {} }`,
+		`{ "name": "bob", "id": 1, "x-a": 1, "x-b": 2, "x-c": 3, "x-d": 4, "e": 5 }`,
+		`[1, 2, 3]`,
+		`"str"`,
+	}
+	for _, test := range tests {
+		want := leafErrors(sch.Validate(unmarshalString(t, test)))
+		err := sch.ValidateReader(strings.NewReader(test))
+		if _, ok := err.(*jsonschema.ValidationError); err != nil && !ok {
+			t.Errorf("%s: unexpected error: %v", test, err)
+			continue
+		}
+		got := leafErrors(err)
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s:\n got: %q\nwant: %q", test, got, want)
+		}
+	}
+
+	// property matching multiple schemas, one referring ancestor
+	rsch := compileString(t, `{"patternProperties": {"^a": {"$ref": "#"}, "^ab": {"type": "object"}}}`)
+	for _, test := range []string{`{"ab": {}}`, `{"ab": 1}`, `{"ab": {"ac": 1}}`} {
+		want := leafErrors(rsch.Validate(unmarshalString(t, test)))
+		got := leafErrors(rsch.ValidateReader(strings.NewReader(test)))
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s:\n got: %q\nwant: %q", test, got, want)
+		}
+	}
+
+	// syntax errors
+	for _, test := range []string{`{ "name": `, `{ "name": "bob" } {}`, `[1, 2`} {
+		err := sch.ValidateReader(strings.NewReader(test))
+		if _, ok := err.(*jsonschema.ValidationError); ok || err == nil {
+			t.Errorf("%s: got %v, want syntax error", test, err)
+		}
+	}
+
+	// errors after top-level value are returned as they are
+	var serr *json.SyntaxError
+	if err := sch.ValidateReader(strings.NewReader(`{ "name": "bob", "id": 1 } x`)); !errors.As(err, &serr) {
+		t.Errorf("got %v, want json.SyntaxError", err)
+	}
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader(`{ "name": "bob", "id": 1 }`), iotest.ErrReader(errRead))
+	if err := sch.ValidateReader(r); !errors.Is(err, errRead) {
+		t.Errorf("got %v, want %v", err, errRead)
+	}
+}
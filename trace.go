@@ -0,0 +1,220 @@
+package jsonschema
+
+import (
+	"strings"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+// tracer records the evaluation of every (sub)schema against
+// every instance location, used for verbose output.
+type tracer struct {
+	root  *traceNode
+	stack []*traceNode
+}
+
+type traceNode struct {
+	sch         *Schema
+	kwLoc       string // keyword location, i.e. evaluation path
+	vloc        []string
+	v           any  // value evaluated
+	complete    bool // all keywords are evaluated
+	err         *ValidationError
+	annotations []Annotation
+	children    []*traceNode
+}
+
+func (t *tracer) begin(vd *validator) *traceNode {
+	node := &traceNode{
+		sch:   vd.sch,
+		kwLoc: vd.scp.kwLoc(),
+		vloc:  append([]string(nil), vd.vloc...),
+	}
+	if top := t.top(); top != nil {
+		top.children = append(top.children, node)
+	} else if t.root == nil {
+		t.root = node
+	}
+	t.stack = append(t.stack, node)
+	return node
+}
+
+func (t *tracer) end(node *traceNode, vd *validator, err error) {
+	t.stack = t.stack[:len(t.stack)-1]
+	node.v = vd.v
+	if err != nil {
+		node.err = err.(*ValidationError)
+	}
+}
+
+func (t *tracer) top() *traceNode {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+// markComplete marks that vd has passed the keywords,
+// which end the evaluation early on failure.
+func (vd *validator) markComplete() {
+	if t := vd.eval.trace; t != nil {
+		t.top().complete = true
+	}
+}
+
+// validAnnotations returns the annotations of node,
+// if it is valid.
+func (node *traceNode) validAnnotations() []Annotation {
+	if node.err != nil {
+		return nil
+	}
+	return node.annotations
+}
+
+// errorList returns the errors reported by node.
+func (node *traceNode) errorList() []*ValidationError {
+	if node.err == nil {
+		return nil
+	}
+	if _, ok := node.err.ErrorKind.(*kind.Group); ok {
+		return node.err.Causes
+	}
+	return []*ValidationError{node.err}
+}
+
+// keywordErrors returns the errors reported by the keywords of
+// node's schema, excluding the errors of its subschemas.
+func (node *traceNode) keywordErrors() []*ValidationError {
+	var result []*ValidationError
+	for _, err := range node.errorList() {
+		if err.ErrorKind == nil {
+			continue // boolResult
+		}
+		if node.failedChild(err) == nil {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// failedChild returns the child, whose error is err.
+func (node *traceNode) failedChild(err *ValidationError) *traceNode {
+	for _, child := range node.children {
+		if child.err == err {
+			return child
+		}
+	}
+	return nil
+}
+
+// failedKeywords returns the keywords of node's schema, that failed
+// either by themselves or because of their subschemas.
+func (node *traceNode) failedKeywords() map[string]bool {
+	failed := map[string]bool{}
+	for _, err := range node.errorList() {
+		if child := node.failedChild(err); child != nil {
+			rel := strings.TrimPrefix(strings.TrimPrefix(child.kwLoc, node.kwLoc), "/")
+			kw, _, _ := strings.Cut(rel, "/")
+			if kw, ok := unescape(kw); ok {
+				failed[kw] = true
+			}
+		} else if err.ErrorKind != nil {
+			failed[errorKeyword(err.ErrorKind)] = true
+		}
+	}
+	return failed
+}
+
+// errorKeyword returns the keyword that reported k.
+func errorKeyword(k ErrorKind) string {
+	if _, ok := k.(*kind.Not); ok {
+		return "not"
+	}
+	if path := k.KeywordPath(); len(path) > 0 {
+		return path[0]
+	}
+	return ""
+}
+
+// evaluatedKeywords returns the keywords of s, that
+// apply to value v.
+func (s *Schema) evaluatedKeywords(v any) []string {
+	var kws []string
+	add := func(present bool, kw string) {
+		if present {
+			kws = append(kws, kw)
+		}
+	}
+
+	// type agnostic --
+	add(s.Ref != nil, "$ref")
+	add(s.RecursiveRef != nil, "$recursiveRef")
+	add(s.DynamicRef != nil, "$dynamicRef")
+	add(s.Types != nil, "type")
+	add(s.Enum != nil, "enum")
+	add(s.Const != nil, "const")
+	add(s.Format != nil || s.formatName != "", "format")
+	add(s.Not != nil, "not")
+	add(s.AllOf != nil, "allOf")
+	add(s.AnyOf != nil, "anyOf")
+	add(s.OneOf != nil, "oneOf")
+	add(s.If != nil, "if")
+
+	switch v.(type) {
+	case map[string]any:
+		add(s.MaxProperties != nil, "maxProperties")
+		add(s.MinProperties != nil, "minProperties")
+		add(s.Required != nil, "required")
+		add(s.PropertyNames != nil, "propertyNames")
+		add(s.Properties != nil, "properties")
+		add(s.PatternProperties != nil, "patternProperties")
+		add(s.AdditionalProperties != nil, "additionalProperties")
+		add(s.Dependencies != nil, "dependencies")
+		add(s.DependentRequired != nil, "dependentRequired")
+		add(s.DependentSchemas != nil, "dependentSchemas")
+		add(s.UnevaluatedProperties != nil, "unevaluatedProperties")
+	case []any:
+		add(s.MinItems != nil, "minItems")
+		add(s.MaxItems != nil, "maxItems")
+		add(s.UniqueItems, "uniqueItems")
+		add(s.Contains != nil, "contains")
+		add(s.MinContains != nil, "minContains")
+		add(s.MaxContains != nil, "maxContains")
+		add(s.PrefixItems != nil, "prefixItems")
+		add(s.Items != nil || s.Items2020 != nil, "items")
+		add(s.AdditionalItems != nil, "additionalItems")
+		add(s.UnevaluatedItems != nil, "unevaluatedItems")
+	case string:
+		add(s.MinLength != nil, "minLength")
+		add(s.MaxLength != nil, "maxLength")
+		add(s.Pattern != nil, "pattern")
+		add(s.ContentEncoding != nil, "contentEncoding")
+		add(s.ContentMediaType != nil, "contentMediaType")
+		add(s.ContentSchema != nil, "contentSchema")
+	}
+	if typeOf(v) == numberType {
+		add(s.Maximum != nil, "maximum")
+		add(s.Minimum != nil, "minimum")
+		add(s.ExclusiveMaximum != nil, "exclusiveMaximum")
+		add(s.ExclusiveMinimum != nil, "exclusiveMinimum")
+		add(s.MultipleOf != nil, "multipleOf")
+	}
+	switch v.(type) {
+	case string, time.Time, *time.Time:
+		add(s.DateMinimum != nil, "dateMinimum")
+		add(s.DateMaximum != nil, "dateMaximum")
+		add(s.DateExclusiveMinimum != nil, "dateExclusiveMinimum")
+		add(s.DateExclusiveMaximum != nil, "dateExclusiveMaximum")
+	}
+
+	// annotations --
+	add(s.Title != "", "title")
+	add(s.Description != "", "description")
+	add(s.Default != nil, "default")
+	add(s.ReadOnly, "readOnly")
+	add(s.WriteOnly, "writeOnly")
+	add(s.Deprecated, "deprecated")
+	add(len(s.Examples) > 0, "examples")
+	return kws
+}
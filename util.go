@@ -284,6 +284,15 @@ func quote(s string) string {
 }
 
 func equals(v1, v2 any) (bool, ErrorKind) {
+	cv1, err := jsonValue(v1)
+	if err != nil {
+		return false, &kind.InvalidJsonValue{Value: v1, Err: err}
+	}
+	cv2, err := jsonValue(v2)
+	if err != nil {
+		return false, &kind.InvalidJsonValue{Value: v2, Err: err}
+	}
+	v1, v2 = cv1, cv2
 	switch v1 := v1.(type) {
 	case map[string]any:
 		v2, ok := v2.(map[string]any)
@@ -361,7 +370,11 @@ func duplicates(arr []any) (int, int, ErrorKind) {
 }
 
 func writeHash(v any, h *maphash.Hash) ErrorKind {
-	switch v := v.(type) {
+	cv, err := jsonValue(v)
+	if err != nil {
+		return &kind.InvalidJsonValue{Value: v, Err: err}
+	}
+	switch v := cv.(type) {
 	case map[string]any:
 		_ = h.WriteByte(0)
 		props := make([]string, 0, len(v))
@@ -460,5 +473,12 @@ func (e *localizableError) Error() string {
 }
 
 func (e *localizableError) LocalizedError(p *message.Printer) string {
-	return p.Sprintf(e.msg, e.args...)
+	args := make([]any, len(e.args))
+	for i, arg := range e.args {
+		if err, ok := arg.(interface{ LocalizedError(*message.Printer) string }); ok {
+			arg = err.LocalizedError(p)
+		}
+		args[i] = arg
+	}
+	return p.Sprintf(e.msg, args...)
 }
@@ -1,11 +1,16 @@
 package jsonschema
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"reflect"
 	"slices"
 	"strconv"
+	"time"
 	"unicode/utf8"
 
 	"github.com/liuxd6825/jsonschema/v6/kind"
@@ -13,10 +18,47 @@ import (
 )
 
 func (sch *Schema) Validate(v any) error {
-	return sch.validate(v, nil, nil, nil, false, nil)
+	return sch.validate(v, nil, nil, nil, false, nil, &evaluation{})
 }
 
-func (sch *Schema) validate(v any, regexpEngine RegexpEngine, meta *Schema, resources map[jsonPointer]*resource, assertVocabs bool, vocabularies map[string]*Vocabulary) error {
+// ValidateContext is like [Schema.Validate] but validation is
+// aborted once ctx is done. In such case [*ValidationCanceledError]
+// is returned.
+func (sch *Schema) ValidateContext(ctx context.Context, v any) error {
+	eval := &evaluation{ctx: ctx}
+	err := sch.validate(v, nil, nil, nil, false, nil, eval)
+	if eval.err != nil {
+		return &ValidationCanceledError{Err: eval.err}
+	}
+	return err
+}
+
+// ValidateOptions controls how much of the instance is
+// validated by [Schema.ValidateWithOptions].
+type ValidateOptions struct {
+	// FailFast stops validation at the first error.
+	// The returned error has exactly one leaf error.
+	FailFast bool
+
+	// MaxErrors stops validation once given number of
+	// errors are found. Zero means no limit.
+	//
+	// Errors of subschemas, which may not contribute to the
+	// result, like failed anyOf/oneOf branches, are not counted.
+	MaxErrors int
+}
+
+// ValidateWithOptions is like [Schema.Validate] but validation
+// is stopped early as specified in opts.
+func (sch *Schema) ValidateWithOptions(v any, opts ValidateOptions) error {
+	eval := &evaluation{maxErrors: opts.MaxErrors}
+	if opts.FailFast {
+		eval.maxErrors = 1
+	}
+	return sch.validate(v, nil, nil, nil, false, nil, eval)
+}
+
+func (sch *Schema) validate(v any, regexpEngine RegexpEngine, meta *Schema, resources map[jsonPointer]*resource, assertVocabs bool, vocabularies map[string]*Vocabulary, eval *evaluation) error {
 	vd := validator{
 		v:            v,
 		vloc:         make([]string, 0, 8),
@@ -30,11 +72,14 @@ func (sch *Schema) validate(v any, regexpEngine RegexpEngine, meta *Schema, reso
 		resources:    resources,
 		assertVocabs: assertVocabs,
 		vocabularies: vocabularies,
+		eval:         eval,
 	}
-	if _, err := vd.validate(); err != nil {
+	_, err := vd.validate()
+	eval.instance = vd.v
+	if err != nil {
 		verr := err.(*ValidationError)
 		var causes []*ValidationError
-		if _, ok := verr.ErrorKind.(*kind.Group); ok {
+		if _, ok := verr.ErrorKind.(*kind.Group); ok && verr.Message == "" {
 			causes = verr.Causes
 		} else {
 			causes = []*ValidationError{verr}
@@ -65,12 +110,103 @@ type validator struct {
 	resources    map[jsonPointer]*resource // resources which should be validated with their dialect
 	assertVocabs bool
 	vocabularies map[string]*Vocabulary
+
+	eval        *evaluation
+	speculative bool  // errors may be discarded by caller. ex: anyOf branch
+	replaced    bool  // v is replaced with new value. ex: array with defaults
+	copied      bool  // v is copy of the container owned by caller
+	ifValid     *bool // result of if, evaluated before filling defaults
+}
+
+// evaluation holds the state shared by all validators
+// involved in single validation.
+type evaluation struct {
+	maxErrors int // 0 means no limit
+	numErrors int
+
+	collectAnnotations bool
+	annotations        []instanceAnnotation
+
+	applyDefaults bool
+	coerceTypes   bool
+	instance      any // instance after validation
+
+	trace *tracer // nil if not tracing
+
+	ctx    context.Context // nil if validation cannot be canceled
+	steps  int             // used to check ctx periodically
+	err    error           // reason for cancellation
+	parent *evaluation     // nested evaluation shares cancellation with parent
+}
+
+// ctx is checked only once in these many steps.
+const cancelCheckInterval = 64
+
+// checkCanceled checks periodically whether ctx is done and
+// reports whether validation must be aborted.
+func (e *evaluation) checkCanceled() bool {
+	if e.parent != nil {
+		return e.parent.checkCanceled()
+	}
+	if e.ctx == nil || e.err != nil {
+		return e.err != nil
+	}
+	e.steps++
+	if e.steps%cancelCheckInterval == 1 {
+		select {
+		case <-e.ctx.Done():
+			e.err = e.ctx.Err()
+		default:
+		}
+	}
+	return e.err != nil
+}
+
+func (e *evaluation) canceled() bool {
+	if e.parent != nil {
+		return e.parent.canceled()
+	}
+	return e.err != nil
+}
+
+func (e *evaluation) limitReached() bool {
+	return e.maxErrors > 0 && e.numErrors >= e.maxErrors
+}
+
+// nested returns evaluation to be used for validating values
+// which are not part of the instance, ex: propertyNames.
+// such validations are not traced.
+func (e *evaluation) nested(speculative bool) *evaluation {
+	if speculative || e.trace != nil {
+		return &evaluation{parent: e}
+	}
+	return e
 }
 
 func (vd *validator) validate() (*uneval, error) {
+	t := vd.eval.trace
+	var node *traceNode
+	if t != nil {
+		node = t.begin(vd)
+	}
+	uneval, err := vd.validateSchema()
+	if err != nil && vd.sch.errorMessages != nil {
+		err = vd.sch.errorMessages.apply(vd, err.(*ValidationError))
+	}
+	if t != nil {
+		t.end(node, vd, err)
+	}
+	return uneval, err
+}
+
+func (vd *validator) validateSchema() (*uneval, error) {
 	s := vd.sch
 	v := vd.v
 
+	if vd.eval.checkCanceled() || vd.halted() {
+		return vd.uneval, nil
+	}
+
 	// boolean --
 	if s.Bool != nil {
 		if *s.Bool {
@@ -91,15 +227,36 @@ func (vd *validator) validate() (*uneval, error) {
 
 	t := typeOf(v)
 	if t == invalidType {
-		return nil, vd.error(&kind.InvalidJsonValue{Value: v})
+		// go value such as struct, typed slice etc
+		// note that schema documents must be json values
+		if vd.meta != nil {
+			return nil, vd.error(&kind.InvalidJsonValue{Value: v})
+		}
+		cv, err := fromGo(v)
+		if err != nil || typeOf(cv) == invalidType {
+			return nil, vd.error(&kind.InvalidJsonValue{Value: v, Err: err})
+		}
+		v, t = cv, typeOf(cv)
+		vd.v, vd.uneval = v, unevalFrom(v, s, false)
+		if vd.eval.applyDefaults || vd.eval.coerceTypes {
+			vd.replaced = true
+		}
 	}
 
 	// type --
 	if s.Types != nil && !s.Types.IsEmpty() {
 		isContains := s.Types.contains(t)
 		isint := s.Types.contains(integerType) && t == numberType && isInteger(v)
-		if !isContains && !isint {
-			return nil, vd.error(&kind.Type{Got: t.String(), Want: s.Types.ToStrings()})
+		if !isContains && !isint && !s.Types.containsTemporal(v) {
+			if !vd.eval.coerceTypes {
+				return nil, vd.error(&kind.Type{Got: t.String(), Want: s.Types.ToStrings()})
+			}
+			cv, ok := coerce(v, *s.Types)
+			if !ok {
+				return nil, vd.error(&kind.Coercion{Got: v, Want: s.Types.ToStrings()})
+			}
+			v, t = cv, typeOf(cv)
+			vd.v, vd.replaced = v, true
 		}
 	}
 
@@ -160,6 +317,8 @@ func (vd *validator) validate() (*uneval, error) {
 		}
 	}
 
+	vd.markComplete()
+
 	// type specific validations --
 	switch v := v.(type) {
 	case map[string]any:
@@ -168,11 +327,14 @@ func (vd *validator) validate() (*uneval, error) {
 		vd.arrValidate(v)
 	case string:
 		vd.strValidate(v)
+		vd.dateValidate(v)
+	case time.Time, *time.Time:
+		vd.dateValidate(v)
 	case json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		vd.numValidate(v)
 	}
 
-	if len(vd.errors) == 0 || !vd.boolResult {
+	if !vd.halted() {
 		if s.DraftVersion >= 2019 {
 			vd.validateRefs()
 		}
@@ -187,6 +349,12 @@ func (vd *validator) validate() (*uneval, error) {
 		}
 	}
 
+	// when tracing, annotations of failed schemas are
+	// recorded, to be reported as dropped annotations
+	if vd.eval.collectAnnotations && (len(vd.errors) == 0 || vd.eval.trace != nil) {
+		vd.annotateSchema()
+	}
+
 	switch len(vd.errors) {
 	case 0:
 		return vd.uneval, nil
@@ -202,6 +370,10 @@ func (vd *validator) validate() (*uneval, error) {
 func (vd *validator) objValidate(obj map[string]any) {
 	s := vd.sch
 
+	if vd.applyingDefaults() {
+		vd.objDefaults(obj)
+	}
+
 	// minProperties --
 	if s.MinProperties != nil {
 		if len(obj) < *s.MinProperties {
@@ -223,7 +395,7 @@ func (vd *validator) objValidate(obj map[string]any) {
 		}
 	}
 
-	if vd.boolResult && len(vd.errors) > 0 {
+	if vd.halted() {
 		return
 	}
 
@@ -243,7 +415,7 @@ func (vd *validator) objValidate(obj map[string]any) {
 
 	var additionalPros []string
 	for pname, pvalue := range obj {
-		if vd.boolResult && len(vd.errors) > 0 {
+		if vd.eval.checkCanceled() || vd.halted() {
 			return
 		}
 		evaluated := false
@@ -252,6 +424,7 @@ func (vd *validator) objValidate(obj map[string]any) {
 		if sch, ok := s.Properties[pname]; ok {
 			evaluated = true
 			vd.addErr(vd.validateVal(sch, pvalue, pname))
+			pvalue = obj[pname] // might be replaced
 		}
 
 		// patternProperties --
@@ -259,6 +432,7 @@ func (vd *validator) objValidate(obj map[string]any) {
 			if regex.MatchString(pname) {
 				evaluated = true
 				vd.addErr(vd.validateVal(sch, pvalue, pname))
+				pvalue = obj[pname] // might be replaced
 			}
 		}
 
@@ -295,7 +469,10 @@ func (vd *validator) objValidate(obj map[string]any) {
 				meta = res.dialect.getSchema(vd.assertVocabs, vd.vocabularies)
 				sch = meta
 			}
-			if err := sch.validate(pname, vd.regexpEngine, meta, resources, vd.assertVocabs, vd.vocabularies); err != nil {
+			mark := len(vd.eval.annotations)
+			err := sch.validate(pname, vd.regexpEngine, meta, resources, vd.assertVocabs, vd.vocabularies, vd.nestedEval())
+			vd.eval.dropAnnotations(mark)
+			if err != nil {
 				verr := err.(*ValidationError)
 				verr.SchemaURL = s.PropertyNames.Location
 				verr.ErrorKind = &kind.PropertyNames{Property: pname}
@@ -328,6 +505,10 @@ func (vd *validator) objValidate(obj map[string]any) {
 func (vd *validator) arrValidate(arr []any) {
 	s := vd.sch
 
+	if vd.applyingDefaults() {
+		arr = vd.arrDefaults(arr)
+	}
+
 	// minItems --
 	if s.MinItems != nil {
 		if len(arr) < *s.MinItems {
@@ -359,12 +540,18 @@ func (vd *validator) arrValidate(arr []any) {
 		switch items := s.Items.(type) {
 		case *Schema:
 			for i, item := range arr {
+				if vd.eval.checkCanceled() || vd.halted() {
+					return
+				}
 				vd.addErr(vd.validateVal(items, item, strconv.Itoa(i)))
 			}
 			evaluated = len(arr)
 		case []*Schema:
 			min := minInt(len(arr), len(items))
 			for i, item := range arr[:min] {
+				if vd.eval.checkCanceled() || vd.halted() {
+					return
+				}
 				vd.addErr(vd.validateVal(items[i], item, strconv.Itoa(i)))
 			}
 			evaluated = min
@@ -379,7 +566,7 @@ func (vd *validator) arrValidate(arr []any) {
 				}
 			case *Schema:
 				for i, item := range arr[evaluated:] {
-					vd.addErr(vd.validateVal(additional, item, strconv.Itoa(i)))
+					vd.addErr(vd.validateVal(additional, item, strconv.Itoa(evaluated+i)))
 				}
 			}
 		}
@@ -388,24 +575,39 @@ func (vd *validator) arrValidate(arr []any) {
 
 		// prefixItems --
 		for i, item := range arr[:evaluated] {
+			if vd.eval.checkCanceled() || vd.halted() {
+				return
+			}
 			vd.addErr(vd.validateVal(s.PrefixItems[i], item, strconv.Itoa(i)))
 		}
 
 		// items2020 --
 		if s.Items2020 != nil {
 			for i, item := range arr[evaluated:] {
-				vd.addErr(vd.validateVal(s.Items2020, item, strconv.Itoa(i)))
+				if vd.eval.checkCanceled() || vd.halted() {
+					return
+				}
+				vd.addErr(vd.validateVal(s.Items2020, item, strconv.Itoa(evaluated+i)))
 			}
 		}
 	}
 
+	if vd.halted() {
+		return
+	}
+
 	// contains --
 	if s.Contains != nil {
 		var errors []*ValidationError
 		var matched []int
 
 		for i, item := range arr {
-			if err := vd.validateVal(s.Contains, item, strconv.Itoa(i)); err != nil {
+			if vd.eval.checkCanceled() {
+				return
+			}
+			if err := vd.speculate(func() error {
+				return vd.validateVal(s.Contains, item, strconv.Itoa(i))
+			}); err != nil {
 				errors = append(errors, err.(*ValidationError))
 			} else {
 				matched = append(matched, i)
@@ -505,7 +707,10 @@ func (vd *validator) strValidate(str string) {
 			meta = res.dialect.getSchema(vd.assertVocabs, vd.vocabularies)
 			sch = meta
 		}
-		if err = sch.validate(*deserialized, vd.regexpEngine, meta, resources, vd.assertVocabs, vd.vocabularies); err != nil {
+		mark := len(vd.eval.annotations)
+		err = sch.validate(*deserialized, vd.regexpEngine, meta, resources, vd.assertVocabs, vd.vocabularies, vd.nestedEval())
+		vd.eval.dropAnnotations(mark)
+		if err != nil {
 			verr := err.(*ValidationError)
 			verr.SchemaURL = s.Location
 			verr.ErrorKind = &kind.ContentSchema{}
@@ -556,6 +761,10 @@ func (vd *validator) numValidate(v any) {
 func (vd *validator) condValidate() {
 	s := vd.sch
 
+	if vd.halted() {
+		return
+	}
+
 	// not --
 	if s.Not != nil {
 		if vd.validateSelf(s.Not, "", true) == nil {
@@ -569,7 +778,7 @@ func (vd *validator) condValidate() {
 		for _, sch := range s.AllOf {
 			if err := vd.validateSelf(sch, "", false); err != nil {
 				errors = append(errors, err.(*ValidationError))
-				if vd.boolResult {
+				if vd.boolResult || vd.limited() {
 					break
 				}
 			}
@@ -584,12 +793,14 @@ func (vd *validator) condValidate() {
 		var matched bool
 		var errors []*ValidationError
 		for _, sch := range s.AnyOf {
-			if err := vd.validateSelf(sch, "", false); err != nil {
+			if err := vd.speculate(func() error {
+				return vd.validateSelf(sch, "", false)
+			}); err != nil {
 				errors = append(errors, err.(*ValidationError))
 			} else {
 				matched = true
-				// for uneval, all schemas must be evaluated
-				if vd.uneval.isEmpty() {
+				// for uneval and annotations, all schemas must be evaluated
+				if vd.uneval.isEmpty() && !vd.eval.collectAnnotations {
 					break
 				}
 			}
@@ -604,7 +815,9 @@ func (vd *validator) condValidate() {
 		var matched = -1
 		var errors []*ValidationError
 		for i, sch := range s.OneOf {
-			if err := vd.validateSelf(sch, "", matched != -1); err != nil {
+			if err := vd.speculate(func() error {
+				return vd.validateSelf(sch, "", matched != -1)
+			}); err != nil {
 				if matched == -1 {
 					errors = append(errors, err.(*ValidationError))
 				}
@@ -624,7 +837,11 @@ func (vd *validator) condValidate() {
 
 	// if, then, else --
 	if s.If != nil {
-		if vd.validateSelf(s.If, "", true) == nil {
+		valid := vd.ifValid != nil && *vd.ifValid
+		if vd.ifValid == nil {
+			valid = vd.validateSelf(s.If, "", true) == nil
+		}
+		if valid {
 			if s.Then != nil {
 				vd.addErr(vd.validateSelf(s.Then, "", false))
 			}
@@ -674,11 +891,19 @@ func (vd *validator) validateSelf(sch *Schema, refKw string, boolResult bool) er
 		resources:    vd.resources,
 		assertVocabs: vd.assertVocabs,
 		vocabularies: vd.vocabularies,
+		eval:         vd.eval,
+		speculative:  vd.speculative,
 	}
 	subvd.handleMeta()
+	mark := len(vd.eval.annotations)
 	uneval, err := subvd.validate()
 	if err == nil {
 		vd.uneval.merge(uneval)
+	} else {
+		vd.eval.dropAnnotations(mark)
+	}
+	if subvd.replaced && err == nil && !boolResult {
+		vd.v, vd.replaced = subvd.v, true
 	}
 	return err
 }
@@ -700,9 +925,18 @@ func (vd *validator) validateVal(sch *Schema, v any, vtok string) error {
 		resources:    vd.resources,
 		assertVocabs: vd.assertVocabs,
 		vocabularies: vd.vocabularies,
+		eval:         vd.eval,
+		speculative:  vd.speculative,
 	}
 	subvd.handleMeta()
+	mark := len(vd.eval.annotations)
 	_, err := subvd.validate()
+	if err != nil {
+		vd.eval.dropAnnotations(mark)
+	}
+	if subvd.replaced && err == nil {
+		vd.replaceItem(vtok, subvd.v)
+	}
 	return err
 }
 
@@ -723,12 +957,72 @@ func (vd *validator) validateValue(sch *Schema, v any, vpath []string) error {
 		resources:    vd.resources,
 		assertVocabs: vd.assertVocabs,
 		vocabularies: vd.vocabularies,
+		eval:         vd.eval,
+		speculative:  vd.speculative,
 	}
 	subvd.handleMeta()
+	mark := len(vd.eval.annotations)
 	_, err := subvd.validate()
+	if err != nil {
+		vd.eval.dropAnnotations(mark)
+	}
 	return err
 }
 
+// replaceItem replaces the value at vtok in current object or array.
+// If the result of vd may be discarded, the container is copied before
+// modifying, so that the caller sees the change only if vd succeeds.
+func (vd *validator) replaceItem(vtok string, v any) {
+	if (vd.speculative || vd.boolResult) && !vd.copied {
+		switch container := vd.v.(type) {
+		case map[string]any:
+			vd.v = maps.Clone(container)
+		case []any:
+			vd.v = slices.Clone(container)
+		}
+		vd.replaced, vd.copied = true, true
+	}
+	switch container := vd.v.(type) {
+	case map[string]any:
+		container[vtok] = v
+	case []any:
+		if i, err := strconv.Atoi(vtok); err == nil && i >= 0 && i < len(container) {
+			container[i] = v
+		}
+	}
+}
+
+// speculate runs f, which validates subschema whose errors the
+// caller may discard. Such errors are not counted towards the
+// error limit.
+func (vd *validator) speculate(f func() error) error {
+	speculative := vd.speculative
+	vd.speculative = true
+	defer func() { vd.speculative = speculative }()
+	return f()
+}
+
+// limited tells whether errors reported by vd must be dropped,
+// because the error limit is already reached.
+func (vd *validator) limited() bool {
+	return !vd.boolResult && !vd.speculative && vd.eval.limitReached()
+}
+
+// halted tells whether vd should stop validating.
+func (vd *validator) halted() bool {
+	if vd.eval.canceled() {
+		return true
+	}
+	if vd.boolResult {
+		return len(vd.errors) > 0
+	}
+	return vd.limited()
+}
+
+func (vd *validator) nestedEval() *evaluation {
+	return vd.eval.nested(vd.speculative || vd.boolResult)
+}
+
 func (vd *validator) metaResource(sch *Schema) *resource {
 	if sch != vd.meta {
 		return nil
@@ -758,7 +1052,7 @@ func (vd *validator) validateRef(sch *Schema, kw string) error {
 	if err != nil {
 		refErr := vd.error(&kind.Reference{Keyword: kw, URL: sch.Location})
 		verr := err.(*ValidationError)
-		if _, ok := verr.ErrorKind.(*kind.Group); ok {
+		if _, ok := verr.ErrorKind.(*kind.Group); ok && verr.Message == "" {
 			refErr.Causes = verr.Causes
 		} else {
 			refErr.Causes = append(refErr.Causes, verr)
@@ -825,11 +1119,15 @@ func (vd *validator) error(kind ErrorKind) *ValidationError {
 	if vd.boolResult {
 		return &ValidationError{}
 	}
+	if !vd.speculative && !isGroupKind(kind) {
+		vd.eval.numErrors++
+	}
 	return &ValidationError{
 		SchemaURL:        vd.sch.Location,
 		InstanceLocation: vd.instanceLocation(),
 		ErrorKind:        kind,
 		Causes:           nil,
+		sch:              vd.sch,
 	}
 }
 
@@ -840,10 +1138,16 @@ func (vd *validator) addErr(err error) {
 }
 
 func (vd *validator) addError(kind ErrorKind) {
+	if vd.limited() {
+		return
+	}
 	vd.errors = append(vd.errors, vd.error(kind))
 }
 
 func (vd *validator) addErrors(errors []*ValidationError, kind ErrorKind) {
+	if vd.limited() && !isGroupKind(kind) {
+		return
+	}
 	err := vd.error(kind)
 	err.Causes = errors
 	vd.errors = append(vd.errors, err)
@@ -862,6 +1166,16 @@ func (vd *validator) findMissing(obj map[string]any, reqd []string) []string {
 	return missing
 }
 
+// isGroupKind tells whether errors of given kind just group
+// the errors reported by subschemas.
+func isGroupKind(k ErrorKind) bool {
+	switch k.(type) {
+	case *kind.Group, *kind.Schema, *kind.Reference, *kind.AllOf:
+		return true
+	}
+	return false
+}
+
 // --
 
 type scope struct {
@@ -971,6 +1285,112 @@ type ValidationError struct {
 
 	// holds nested errors
 	Causes []*ValidationError
+
+	// custom message, that replaces message of ErrorKind.
+	// set from errorMessage keyword, see [ErrorMessageVocab].
+	Message string
+
+	// positions of the instance value and the failing keyword
+	// in source documents. set by [ValidationError.ResolvePositions].
+	InstancePosition *SourcePosition
+	KeywordPosition  *SourcePosition
+
+	sch *Schema // schema that reported the error
+}
+
+// Unwrap returns the causes, so that [errors.Is] and [errors.As]
+// search the whole tree of errors.
+func (e *ValidationError) Unwrap() []error {
+	if len(e.Causes) == 0 {
+		return nil
+	}
+	errs := make([]error, len(e.Causes))
+	for i, cause := range e.Causes {
+		errs[i] = cause
+	}
+	return errs
+}
+
+// Is reports whether target is an [ErrorKind] of the same type
+// as e.ErrorKind. Field values of target are ignored, so that
+// errors.Is(err, &kind.Required{}) tells whether any property
+// is missing anywhere in the tree.
+func (e *ValidationError) Is(target error) bool {
+	k, ok := target.(ErrorKind)
+	return ok && e.ErrorKind != nil && reflect.TypeOf(k) == reflect.TypeOf(e.ErrorKind)
+}
+
+// As sets target to e.ErrorKind, if target is a pointer to
+// a type that e.ErrorKind is assignable to, ex:
+//
+//	var req *kind.Required
+//	if errors.As(err, &req) {
+//		fmt.Println(req.Missing)
+//	}
+//
+// Otherwise target is matched against the error wrapped by
+// e.ErrorKind, such as [UnsupportedValueError] wrapped by
+// [kind.InvalidJsonValue] for go values that cannot be converted.
+func (e *ValidationError) As(target any) bool {
+	if e.ErrorKind == nil {
+		return false
+	}
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return false
+	}
+	kv := reflect.ValueOf(e.ErrorKind)
+	if !kv.Type().AssignableTo(rv.Elem().Type()) {
+		// kinds such as InvalidJsonValue wrap the underlying error
+		if w, ok := e.ErrorKind.(interface{ Unwrap() error }); ok && w.Unwrap() != nil {
+			return errors.As(w.Unwrap(), target)
+		}
+		return false
+	}
+	rv.Elem().Set(kv)
+	return true
+}
+
+// Find returns the first error in the tree, in depth-first order,
+// whose ErrorKind is of the same type as k, ex: verr.Find(&kind.Required{}).
+// It returns nil, if there is no such error.
+func (e *ValidationError) Find(k ErrorKind) *ValidationError {
+	if found := e.FindAll(k); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// FindAll returns all errors in the tree, in depth-first order,
+// whose ErrorKind is of the same type as k.
+func (e *ValidationError) FindAll(k ErrorKind) []*ValidationError {
+	var found []*ValidationError
+	var find func(e *ValidationError)
+	find = func(e *ValidationError) {
+		if e.ErrorKind != nil && reflect.TypeOf(e.ErrorKind) == reflect.TypeOf(k) {
+			found = append(found, e)
+		}
+		for _, cause := range e.Causes {
+			find(cause)
+		}
+	}
+	find(e)
+	return found
+}
+
+// ValidationCanceledError is returned by [Schema.ValidateContext]
+// when its context is done before validation completes.
+type ValidationCanceledError struct {
+	// Err is the context error. ex: context.DeadlineExceeded
+	Err error
+}
+
+func (e *ValidationCanceledError) Error() string {
+	return fmt.Sprintf("validation canceled: %v", e.Err)
+}
+
+func (e *ValidationCanceledError) Unwrap() error {
+	return e.Err
 }
 
 type ErrorKind interface {
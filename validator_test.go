@@ -0,0 +1,250 @@
+package jsonschema_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/liuxd6825/jsonschema/v6"
+	"github.com/liuxd6825/jsonschema/v6/kind"
+)
+
+func compileString(t *testing.T, schema string) *jsonschema.Schema {
+	t.Helper()
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sch
+}
+
+func unmarshalString(t *testing.T, s string) any {
+	t.Helper()
+	v, err := jsonschema.UnmarshalJSON(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// countLeafErrors counts errors the way error limit does,
+// i.e causes of failed anyOf are not counted.
+func countLeafErrors(verr *jsonschema.ValidationError) int {
+	if _, ok := verr.ErrorKind.(*kind.AnyOf); ok || len(verr.Causes) == 0 {
+		return 1
+	}
+	n := 0
+	for _, cause := range verr.Causes {
+		n += countLeafErrors(cause)
+	}
+	return n
+}
+
+func TestValidateWithOptions(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {
+				"name": { "type": "string", "minLength": 3 },
+				"age": { "type": "integer", "minimum": 0 }
+			},
+			"anyOf": [
+				{ "required": ["name"] },
+				{ "required": ["id"] }
+			]
+		}
+	}`)
+	inst := unmarshalString(t, `[
+		{ "name": "a", "age": -1 },
+		{ "name": 1, "age": "x" },
+		{ "age": 2 },
+		{ "name": "bob", "age": 3 }
+	]`)
+
+	tests := []struct {
+		name string
+		opts jsonschema.ValidateOptions
+		want int
+	}{
+		{"unlimited", jsonschema.ValidateOptions{}, 5},
+		{"failFast", jsonschema.ValidateOptions{FailFast: true}, 1},
+		{"maxErrors", jsonschema.ValidateOptions{MaxErrors: 3}, 3},
+		{"maxErrorsAboveTotal", jsonschema.ValidateOptions{MaxErrors: 10}, 5},
+	}
+	for _, test := range tests {
+		err := sch.ValidateWithOptions(inst, test.opts)
+		if err == nil {
+			t.Errorf("%s: validation must fail", test.name)
+			continue
+		}
+		if got := countLeafErrors(err.(*jsonschema.ValidationError)); got != test.want {
+			t.Errorf("%s: got %d errors, want %d\n%v", test.name, got, test.want, err)
+		}
+	}
+
+	// failed anyOf branches must not stop the validation
+	valid := unmarshalString(t, `[{ "id": 1 }, { "name": "bob" }]`)
+	if err := sch.ValidateWithOptions(valid, jsonschema.ValidateOptions{FailFast: true}); err != nil {
+		t.Errorf("valid instance failed: %v", err)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	sch := compileString(t, `{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {
+				"id": { "type": "integer" },
+				"tags": { "type": "array", "items": { "type": "string" } }
+			}
+		},
+		"uniqueItems": true
+	}`)
+	var arr []any
+	for i := 0; i < 1000; i++ {
+		arr = append(arr, map[string]any{"id": i, "tags": []any{"a", "b", "c"}})
+	}
+
+	if err := sch.ValidateContext(context.Background(), arr); err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := sch.ValidateContext(ctx, arr)
+	if _, ok := err.(*jsonschema.ValidationCanceledError); !ok {
+		t.Fatalf("got %#v, want *ValidationCanceledError", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("errors.Is(%v, context.Canceled) must be true", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if err := sch.ValidateContext(ctx, arr); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestItemsInstanceLocation(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{`{"prefixItems": [{}], "items": {"type": "string"}}`, "2"},
+		{`{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"items": [{}],
+			"additionalItems": {"type": "string"}
+		}`, "2"},
+	}
+	for _, test := range tests {
+		sch := compileString(t, test.schema)
+		err := sch.Validate([]any{1, "a", 2})
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			t.Fatalf("got %v, want ValidationError", err)
+		}
+		for len(verr.Causes) > 0 {
+			verr = verr.Causes[0]
+		}
+		if got := strings.Join(verr.InstanceLocation, "/"); got != test.want {
+			t.Errorf("%s: instanceLocation: got %q, want %q", test.schema, got, test.want)
+		}
+	}
+}
+
+func TestValidationErrorUnwrap(t *testing.T) {
+	sch := compileString(t, `{
+		"properties": {
+			"user": { "$ref": "#/$defs/user" }
+		},
+		"$defs": {
+			"user": {
+				"properties": {
+					"name": { "type": "string", "minLength": 3 }
+				},
+				"required": ["id", "name"]
+			}
+		}
+	}`)
+	err := sch.Validate(unmarshalString(t, `{"user": {"name": "ab"}}`))
+	if err == nil {
+		t.Fatal("validation must fail")
+	}
+
+	if !errors.Is(err, &kind.Required{}) {
+		t.Error("errors.Is must find *kind.Required")
+	}
+	if !errors.Is(err, &kind.MinLength{}) {
+		t.Error("errors.Is must find *kind.MinLength")
+	}
+	if errors.Is(err, &kind.Type{}) {
+		t.Error("errors.Is must not find *kind.Type")
+	}
+
+	var req *kind.Required
+	if !errors.As(err, &req) {
+		t.Fatal("errors.As must find *kind.Required")
+	}
+	if strings.Join(req.Missing, ",") != "id" {
+		t.Errorf("got missing %v, want [id]", req.Missing)
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) || verr != err {
+		t.Error("errors.As must find *ValidationError itself")
+	}
+
+	found := verr.Find(&kind.MinLength{})
+	if found == nil || strings.Join(found.InstanceLocation, "/") != "user/name" {
+		t.Fatalf("got %v, want minLength error at /user/name", found)
+	}
+	if found.ErrorKind.(*kind.MinLength).Want != 3 {
+		t.Errorf("got %#v", found.ErrorKind)
+	}
+	if verr.Find(&kind.Enum{}) != nil {
+		t.Error("Find must return nil for missing kind")
+	}
+	if got := len(verr.FindAll(&kind.Reference{})); got != 1 {
+		t.Errorf("got %d reference errors, want 1", got)
+	}
+}
+
+func TestCoerceTypesSpeculative(t *testing.T) {
+	sch := compileString(t, `{
+		"anyOf": [
+			{ "properties": { "a": { "type": "integer" } }, "required": ["b"] },
+			{ "properties": { "a": { "type": "string" } } }
+		]
+	}`)
+	v, err := sch.CoerceTypes(unmarshalString(t, `{"a": "1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.(map[string]any)["a"]; got != "1" {
+		t.Errorf("a: got %#v, want %q", got, "1")
+	}
+
+	// coercion of winning branch is applied
+	v, err = sch.CoerceTypes(unmarshalString(t, `{"a": "1", "b": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.(map[string]any)["a"]; got != json.Number("1") {
+		t.Errorf("a: got %#v, want 1", got)
+	}
+}
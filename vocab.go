@@ -43,6 +43,12 @@ type SchemaExt interface {
 	Validate(ctx *ValidatorContext, v any)
 }
 
+// schemaAdjuster is implemented by extensions of builtin
+// vocabularies, which update the schema they are compiled into.
+type schemaAdjuster interface {
+	adjust(s *Schema)
+}
+
 // ValidatorContext provides helpers for
 // validating with [SchemaExt].
 type ValidatorContext struct {
@@ -76,6 +82,13 @@ func (ctx *ValidatorContext) EvaluatedItem(index int) {
 	delete(ctx.vd.uneval.items, index)
 }
 
+// Annotate attaches annotation produced by given keyword to the
+// current value. It is reported by [Schema.CollectAnnotations] only if
+// the schema validates successfully.
+func (ctx *ValidatorContext) Annotate(keyword string, value any) {
+	ctx.vd.annotate(keyword, value)
+}
+
 // AddError reports validation-error of given kind.
 func (ctx *ValidatorContext) AddError(k ErrorKind) {
 	ctx.vd.addError(k)